@@ -0,0 +1,190 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+	"gopkg.in/yaml.v3"
+)
+
+// CacheStatusHeader tells on responses answered by a Cache whether the entry was
+// served as is or revalidated with Jenkins.
+const CacheStatusHeader = "X-Jenkins-Cli-Cache"
+
+type cacheModeKey struct{}
+
+type cacheMode int
+
+const (
+	cacheUse cacheMode = iota + 1
+	cacheRefresh
+)
+
+// cacheable marks req as safe to answer from a Cache, for data that rarely changes
+// between two commands such as views, job parameters and crumbs. A refreshed request
+// always goes to Jenkins, its answer replacing the cached one.
+func cacheable(req *http.Request, refresh bool) *http.Request {
+	mode := cacheUse
+	if refresh {
+		mode = cacheRefresh
+	}
+	return req.WithContext(context.WithValue(req.Context(), cacheModeKey{}, mode))
+}
+
+// Cache is a transport keeping the responses of cacheable GET requests on disk, one
+// directory per Jenkins user and host. Entries carrying an ETag or Last-Modified
+// header are revalidated with a conditional request, others are served until they
+// are older than the TTL. Responses setting a cookie are never stored. A POST clears
+// the entries of its user and host since it may have changed any of them, or been
+// refused for a stale cached crumb.
+type Cache struct {
+	dir  string
+	ttl  time.Duration
+	next http.RoundTripper
+	now  func() time.Time
+}
+
+func NewCache(dir string, ttl time.Duration, next http.RoundTripper) *Cache {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Cache{dir: dir, ttl: ttl, next: next, now: time.Now}
+}
+
+// ClearCache removes every entry of a cache directory.
+func ClearCache(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	return nil
+}
+
+func (c *Cache) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		response, err := c.next.RoundTrip(req)
+		if err == nil {
+			os.RemoveAll(c.accountDir(req))
+		}
+		return response, err
+	}
+	mode, _ := req.Context().Value(cacheModeKey{}).(cacheMode)
+	if mode == 0 {
+		return c.next.RoundTrip(req)
+	}
+
+	path := filepath.Join(c.accountDir(req), cacheKey(req.URL.String())+".yaml")
+	entry, found := c.load(path)
+	if mode == cacheRefresh {
+		found = false
+	}
+	conditional := req
+	if found {
+		if entry.ETag == "" && entry.LastModified == "" {
+			if c.now().Sub(entry.StoredAt) < c.ttl {
+				return c.response(req, entry, "HIT"), nil
+			}
+		} else {
+			conditional = req.Clone(req.Context())
+			if entry.ETag != "" {
+				conditional.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				conditional.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
+	response, err := c.next.RoundTrip(conditional)
+	if err != nil {
+		return nil, err
+	}
+	if found && response.StatusCode == http.StatusNotModified {
+		io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+		entry.StoredAt = c.now()
+		c.store(path, entry)
+		return c.response(req, entry, "REVALIDATED"), nil
+	}
+	// Responses setting a cookie belong to the session of this process, such as
+	// session-bound crumbs.
+	if response.StatusCode != http.StatusOK || len(response.Header.Values("Set-Cookie")) > 0 {
+		return response, nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	c.store(path, config.CacheEntry{
+		Url:          req.URL.String(),
+		StoredAt:     c.now(),
+		ETag:         response.Header.Get("ETag"),
+		LastModified: response.Header.Get("Last-Modified"),
+		Header:       response.Header,
+		Body:         string(body),
+	})
+	return response, nil
+}
+
+func (c *Cache) response(req *http.Request, entry config.CacheEntry, status string) *http.Response {
+	response := storedResponse(req, http.StatusOK, entry.Header, entry.Body)
+	response.Header.Set(CacheStatusHeader, status)
+	return response
+}
+
+// accountDir is the directory of the entries of the user and host of req.
+func (c *Cache) accountDir(req *http.Request) string {
+	username, _, _ := req.BasicAuth()
+	return filepath.Join(c.dir, cacheKey(username + "@" + req.URL.Host)[:16])
+}
+
+func (c *Cache) load(path string) (config.CacheEntry, bool) {
+	var entry config.CacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, false
+	}
+	if err := yaml.Unmarshal(data, &entry); err != nil {
+		return entry, false
+	}
+	return entry, true
+}
+
+// store writes entry, a cache that cannot be written only costs a request later.
+// Entries are renamed into place so a concurrent command never reads half of one.
+func (c *Cache) store(path string, entry config.CacheEntry) {
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+	temp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return
+	}
+	_, err = temp.Write(data)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(temp.Name(), path) != nil {
+		os.Remove(temp.Name())
+	}
+}
+
+func cacheKey(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
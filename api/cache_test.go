@@ -0,0 +1,96 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func newTestCache(t *testing.T) *Cache {
+	t.Helper()
+	cache := NewCache(t.TempDir(), time.Minute, nil)
+	SetTransport(cache)
+	t.Cleanup(func() { SetTransport(nil) })
+	return cache
+}
+
+func TestCacheServesWithinTTL(t *testing.T) {
+	server, account := newTestServer(t)
+	cache := newTestCache(t)
+	for range 3 {
+		if views, err := GetViews(account); err != nil || len(views) != 3 {
+			t.Fatalf("GetViews = %v, %v", views, err)
+		}
+	}
+	if requests := len(server.RequestsTo(http.MethodGet, "/api/json")); requests != 1 {
+		t.Errorf("%d requests within the TTL, want 1", requests)
+	}
+
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	GetViews(account)
+	if requests := len(server.RequestsTo(http.MethodGet, "/api/json")); requests != 2 {
+		t.Errorf("%d requests after the TTL, want 2", requests)
+	}
+
+	// Other accounts and requests that are not cacheable always reach Jenkins.
+	other := account
+	other.Username = "someone"
+	server.Username = ""
+	GetViews(other)
+	GetBuildSummary(account, "svc-api", "1")
+	GetBuildSummary(account, "svc-api", "1")
+	if requests := len(server.RequestsTo(http.MethodGet, "/api/json")); requests != 3 {
+		t.Errorf("%d requests for another account, want 3", requests)
+	}
+	if requests := len(server.RequestsTo(http.MethodGet, "/job/svc-api/1/api/json")); requests != 2 {
+		t.Errorf("%d build requests, want 2", requests)
+	}
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	server, account := newTestServer(t)
+	newTestCache(t)
+	body := `{"property":[{"_class":"hudson.model.ParametersDefinitionProperty","parameterDefinitions":[` +
+		`{"_class":"hudson.model.ChoiceParameterDefinition","choices":["dev","prod"]}]}]}`
+	server.Handle(http.MethodGet, "/job/svc-api/api/json", 0, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Write([]byte(body))
+	})
+
+	for range 2 {
+		choices, _, err := GetJobParams(account, "svc-api")
+		if err != nil || len(choices) != 2 {
+			t.Fatalf("GetJobParams = %v, %v", choices, err)
+		}
+	}
+	requests := server.RequestsTo(http.MethodGet, "/job/svc-api/api/json")
+	if len(requests) != 2 || requests[1].Header.Get("If-None-Match") != `"v1"` {
+		t.Errorf("revalidation requests = %+v", requests)
+	}
+}
+
+func TestCacheRefreshAndInvalidation(t *testing.T) {
+	server, account := newTestServer(t)
+	newTestCache(t)
+	GetViews(account)
+
+	refresh := account
+	refresh.RefreshCache = true
+	GetViews(refresh)
+	if requests := len(server.RequestsTo(http.MethodGet, "/api/json")); requests != 2 {
+		t.Fatalf("%d requests after a refresh, want 2", requests)
+	}
+
+	server.AddView("ops")
+	if err := EnableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	views, err := GetViews(account)
+	if err != nil || len(views) != 4 {
+		t.Errorf("GetViews after a POST = %v, %v", views, err)
+	}
+}
@@ -0,0 +1,213 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Headers that carry credentials or session state, crumb headers are matched by name.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+var slugPattern = regexp.MustCompile(`[^A-Za-z0-9]+`)
+var crumbPattern = regexp.MustCompile(`"crumb"\s*:\s*"[^"]*"`)
+
+// Recorder is a transport that writes every request and response to a cassette
+// directory, one numbered file per interaction. Recording into a directory that
+// already holds a cassette appends to it.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+	mu   sync.Mutex
+	seq  int
+}
+
+func NewRecorder(dir string, next http.RoundTripper) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cassette directory: %w", err)
+	}
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{dir: dir, next: next, seq: len(files)}, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestBody = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	response, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := config.Interaction{
+		Method:         req.Method,
+		Url:            req.URL.RequestURI(),
+		RequestHeader:  redactHeader(req.Header),
+		RequestBody:    string(requestBody),
+		Status:         response.StatusCode,
+		ResponseHeader: redactHeader(response.Header),
+		ResponseBody:   string(redactBody(req, responseBody)),
+	}
+	if err := r.write(interaction); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (r *Recorder) write(interaction config.Interaction) error {
+	data, err := yaml.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	slug := strings.Trim(slugPattern.ReplaceAllString(strings.SplitN(interaction.Url, "?", 2)[0], "-"), "-")
+	if len(slug) > 60 {
+		slug = slug[:60]
+	}
+	name := fmt.Sprintf("%04d-%s-%s.yaml", r.seq, interaction.Method, slug)
+	if err := os.WriteFile(filepath.Join(r.dir, name), data, 0644); err != nil {
+		return fmt.Errorf("failed to record interaction: %w", err)
+	}
+	return nil
+}
+
+// Replayer is a transport that answers from a cassette written by a Recorder instead
+// of the network. Requests are matched on method, path and query regardless of the
+// host, repeated requests get the recorded responses in order and the last one once
+// those run out, so polling loops settle on the final recorded state.
+type Replayer struct {
+	mu           sync.Mutex
+	interactions map[string][]config.Interaction
+	served       map[string]int
+}
+
+func NewReplayer(dir string) (*Replayer, error) {
+	files, err := cassetteFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no recorded interactions in %s", dir)
+	}
+	replayer := &Replayer{interactions: make(map[string][]config.Interaction), served: make(map[string]int)}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var interaction config.Interaction
+		if err := yaml.Unmarshal(data, &interaction); err != nil {
+			return nil, fmt.Errorf("invalid interaction %s: %w", filepath.Base(file), err)
+		}
+		key := interaction.Method + " " + interaction.Url
+		replayer.interactions[key] = append(replayer.interactions[key], interaction)
+	}
+	return replayer, nil
+}
+
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	key := req.Method + " " + req.URL.RequestURI()
+	r.mu.Lock()
+	recorded := r.interactions[key]
+	index := min(r.served[key], len(recorded)-1)
+	r.served[key]++
+	r.mu.Unlock()
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("no recorded response for %s", key)
+	}
+
+	interaction := recorded[index]
+	return storedResponse(req, interaction.Status, interaction.ResponseHeader, interaction.ResponseBody), nil
+}
+
+// storedResponse builds the response to req from a status, headers and body kept
+// by a cassette or the cache.
+func storedResponse(req *http.Request, status int, storedHeader map[string][]string, body string) *http.Response {
+	header := http.Header{}
+	for name, values := range storedHeader {
+		header[name] = slices.Clone(values)
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// cassetteFiles lists the interaction files of dir in recording order.
+func cassetteFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sequence := func(file string) int {
+		number, _ := strconv.Atoi(strings.SplitN(filepath.Base(file), "-", 2)[0])
+		return number
+	}
+	slices.SortFunc(files, func(a, b string) int { return sequence(a) - sequence(b) })
+	return files, nil
+}
+
+func redactHeader(header http.Header) map[string][]string {
+	if len(header) == 0 {
+		return nil
+	}
+	redacted := make(map[string][]string, len(header))
+	for name, values := range header {
+		if slices.Contains(redactedHeaders, http.CanonicalHeaderKey(name)) || strings.Contains(strings.ToLower(name), "crumb") {
+			values = []string{config.REDACTED_VALUE}
+		}
+		redacted[name] = slices.Clone(values)
+	}
+	return redacted
+}
+
+// redactBody hides the crumb the crumb issuer hands out.
+func redactBody(req *http.Request, body []byte) []byte {
+	if !strings.HasSuffix(req.URL.Path, "/crumbIssuer/api/json") {
+		return body
+	}
+	return crumbPattern.ReplaceAll(body, []byte(`"crumb":"`+config.REDACTED_VALUE+`"`))
+}
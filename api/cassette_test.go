@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server, account := newTestServer(t)
+	dir := t.TempDir()
+	recorder, err := NewRecorder(dir, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTransport(recorder)
+	t.Cleanup(func() { SetTransport(nil) })
+
+	views, err := GetViews(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, err := GetBuildSummary(account, "svc-api", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := DisableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if len(files) != 4 {
+		t.Fatalf("recorded %d interactions, want 4: %v", len(files), files)
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		if strings.Contains(string(data), "Basic ") || strings.Contains(string(data), jenkinstest.DefaultCrumb) {
+			t.Errorf("%s leaks credentials:\n%s", filepath.Base(file), data)
+		}
+	}
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTransport(replayer)
+	replayedViews, err := GetViews(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayedSummary, err := GetBuildSummary(account, "svc-api", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(replayedViews, views) || !reflect.DeepEqual(replayedSummary, summary) {
+		t.Errorf("replayed %v %+v, recorded %v %+v", replayedViews, replayedSummary, views, summary)
+	}
+	if err := DisableJob(account, "infra"); err != nil {
+		t.Errorf("replayed DisableJob: %v", err)
+	}
+	if _, err := GetViewJob(account, "team"); err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("unrecorded request returned %v", err)
+	}
+}
+
+func TestReplayRepeatsInOrder(t *testing.T) {
+	server, account := newTestServer(t)
+	build := server.AddBuild("svc-api", &jenkinstest.Build{Building: true})
+	dir := t.TempDir()
+	recorder, _ := NewRecorder(dir, nil)
+	SetTransport(recorder)
+	t.Cleanup(func() { SetTransport(nil) })
+
+	GetBuildSummary(account, "svc-api", "3")
+	server.Update(func() { build.Building, build.Result = false, "SUCCESS" })
+	GetBuildSummary(account, "svc-api", "3")
+
+	replayer, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetTransport(replayer)
+	var results []bool
+	for range 3 {
+		summary, err := GetBuildSummary(account, "svc-api", "3")
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, summary.Building)
+	}
+	if !reflect.DeepEqual(results, []bool{true, false, false}) {
+		t.Errorf("replayed building states = %v", results)
+	}
+}
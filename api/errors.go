@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// StatusError is a request Jenkins answered with an error status. Message is the
+// reason Jenkins gave, taken from the X-Error header or the text of the error page,
+// and Body is the raw response.
+type StatusError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s %s: %s", e.Method, e.Path, e.Message)
+	}
+	text := fmt.Sprintf("%s %s: request failed with status code: %d", e.Method, e.Path, e.StatusCode)
+	if e.Message != "" {
+		text += " (" + e.Message + ")"
+	}
+	return text
+}
+
+// NotFoundError is a 404, the job, view, build or queue item does not exist.
+type NotFoundError struct{ StatusError }
+
+// UnauthorizedError is a 401, the account's username or token is wrong.
+type UnauthorizedError struct{ StatusError }
+
+// ForbiddenError is a 403 other than a crumb rejection, the user lacks a permission.
+type ForbiddenError struct{ StatusError }
+
+// CrumbError is a POST rejected for a missing or stale crumb, also after a retry with
+// a fresh crumb.
+type CrumbError struct{ StatusError }
+
+// NetworkError is a request that got no response from Jenkins.
+type NetworkError struct {
+	Method string
+	Path   string
+	Err    error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("%s %s: request failed: %v", e.Method, e.Path, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error { return e.Err }
+
+// TimeoutError is a NetworkError caused by a deadline.
+type TimeoutError struct{ NetworkError }
+
+// StatusCode returns the HTTP status of an api error, 0 when Jenkins did not answer.
+func StatusCode(err error) int {
+	var status interface{ statusError() *StatusError }
+	if errors.As(err, &status) {
+		return status.statusError().StatusCode
+	}
+	return 0
+}
+
+func (e *StatusError) statusError() *StatusError { return e }
+
+var (
+	errorTagPattern   = regexp.MustCompile(`(?s)<(script|style|head)[^>]*>.*?</(script|style|head)>|<[^>]+>`)
+	errorSpacePattern = regexp.MustCompile(`\s+`)
+)
+
+const maxErrorMessage = 200
+
+// responseError classifies a response with status >= 400 by its status code.
+func responseError(req *http.Request, response *http.Response, body []byte) error {
+	status := StatusError{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: response.StatusCode,
+		Message:    errorMessage(response.Header, body),
+		Body:       string(body),
+	}
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return &NotFoundError{status}
+	case response.StatusCode == http.StatusUnauthorized:
+		return &UnauthorizedError{status}
+	case response.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(string(body)), "no valid crumb"):
+		return &CrumbError{status}
+	case response.StatusCode == http.StatusForbidden:
+		return &ForbiddenError{status}
+	}
+	return &status
+}
+
+// requestError wraps a transport failure, telling timeouts apart. The *url.Error
+// layer is dropped since it repeats the method and URL.
+func requestError(req *http.Request, err error) error {
+	var netErr net.Error
+	timeout := errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	network := NetworkError{Method: req.Method, Path: req.URL.Path, Err: err}
+	if timeout {
+		return &TimeoutError{network}
+	}
+	return &network
+}
+
+// errorMessage extracts the reason from a Jenkins error response: the X-Error header
+// Stapler sets, or the text of the HTML error page shortened to one line.
+func errorMessage(header http.Header, body []byte) string {
+	if message := header.Get("X-Error"); message != "" {
+		return message
+	}
+	text := errorTagPattern.ReplaceAllString(string(body), " ")
+	text = strings.TrimSpace(errorSpacePattern.ReplaceAllString(html.UnescapeString(text), " "))
+	if runes := []rune(text); len(runes) > maxErrorMessage {
+		text = string(runes[:maxErrorMessage]) + "..."
+	}
+	return text
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorTypes(t *testing.T) {
+	server, account := newTestServer(t)
+
+	_, _, err := GetJobParams(account, "missing")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) || StatusCode(err) != http.StatusNotFound || notFound.Path != "/job/missing/api/json" {
+		t.Errorf("unknown job = %#v", err)
+	}
+
+	wrong := account
+	wrong.Token = "wrong"
+	var unauthorized *UnauthorizedError
+	if _, err := GetViews(wrong); !errors.As(err, &unauthorized) {
+		t.Errorf("wrong token = %#v", err)
+	}
+
+	server.Handle(http.MethodPost, "/job/svc-api/2/stop", 0, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "No valid crumb was included in the request", http.StatusForbidden)
+	})
+	_, err = Stop(account, "svc-api", "2")
+	var crumb *CrumbError
+	if !errors.As(err, &crumb) || crumb.Message != "No valid crumb was included in the request" {
+		t.Errorf("POST with a refused crumb = %#v", err)
+	}
+	if requests := len(server.RequestsTo(http.MethodPost, "/job/svc-api/2/stop")); requests != 2 {
+		t.Errorf("refused crumb sent %d times, want a single retry", requests)
+	}
+
+	server.Handle(http.MethodPost, "/job/infra/disable", 1, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("<html><head><title>Error</title></head><body><h1>Access Denied</h1>\n<p>tester is missing the Job/Configure permission</p></body></html>"))
+	})
+	var forbidden *ForbiddenError
+	if err := DisableJob(account, "infra"); !errors.As(err, &forbidden) || forbidden.Message != "Access Denied tester is missing the Job/Configure permission" {
+		t.Errorf("missing permission = %#v", err)
+	}
+
+	server.Handle(http.MethodPost, "/createItem", 1, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Error", "A job already exists with the name 'infra'")
+		http.Error(w, "<html>stack trace</html>", http.StatusBadRequest)
+	})
+	err = CreateJob(account, "infra", "<project/>")
+	if StatusCode(err) != http.StatusBadRequest || !strings.Contains(err.Error(), "A job already exists with the name 'infra'") {
+		t.Errorf("X-Error message = %v", err)
+	}
+
+	server.Close()
+	var network *NetworkError
+	if _, err := GetViews(account); !errors.As(err, &network) || StatusCode(err) != 0 {
+		t.Errorf("closed server = %#v", err)
+	}
+}
+
+func TestTimeoutError(t *testing.T) {
+	server, account := newTestServer(t)
+	server.Handle(http.MethodGet, "/api/json", 1, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	})
+	timeout := requestTimeout
+	requestTimeout = 20 * time.Millisecond
+	t.Cleanup(func() { requestTimeout = timeout })
+
+	_, err := GetViews(account)
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Errorf("slow server = %#v", err)
+	}
+}
@@ -1,8 +1,14 @@
 package api
 
 import (
+	"bytes"
+	"crypto/sha1"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"io"
 	"net/http"
 	"net/url"
@@ -12,7 +18,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/fatih/color"
 	"github.com/lemonsoul/jenkins-cli/config"
 	"github.com/tidwall/gjson"
 )
@@ -25,6 +30,16 @@ func buildRequest(cfg config.JenkinsConfig, req *http.Request) error {
 }
 
 func baseReq(cfg config.JenkinsConfig, api string, params map[string]string) ([]byte, int, http.Header, error) {
+	return getReq(cfg, api, params, false)
+}
+
+// cachedReq is baseReq for data that rarely changes, which a Cache transport may
+// answer without asking Jenkins.
+func cachedReq(cfg config.JenkinsConfig, api string, params map[string]string) ([]byte, int, http.Header, error) {
+	return getReq(cfg, api, params, true)
+}
+
+func getReq(cfg config.JenkinsConfig, api string, params map[string]string, cached bool) ([]byte, int, http.Header, error) {
 	apiUrl, _ := url.JoinPath(cfg.BaseApi, api)
 
 	urlParams := url.Values{}
@@ -35,7 +50,8 @@ func baseReq(cfg config.JenkinsConfig, api string, params map[string]string) ([]
 		}
 	}
 
-	fullUrl, _ := url.Parse(apiUrl + "?" + urlParams.Encode())
+	fullUrl, _ := url.Parse(apiUrl)
+	fullUrl.RawQuery = urlParams.Encode()
 
 	req, err := http.NewRequest("GET", fullUrl.String(), nil)
 	if err != nil {
@@ -45,52 +61,369 @@ func baseReq(cfg config.JenkinsConfig, api string, params map[string]string) ([]
 	if err := buildRequest(cfg, req); err != nil {
 		return nil, -1, nil, err
 	}
-	response, err := http.DefaultClient.Do(req)
+	if cached {
+		req = cacheable(req, cfg.RefreshCache)
+	}
+	return doReq(httpClient(cfg, true), req)
+}
+
+// doReq sends req and reads the whole response, turning transport failures and
+// error statuses into the typed errors of errors.go.
+func doReq(client *http.Client, req *http.Request) ([]byte, int, http.Header, error) {
+	response, err := client.Do(req)
 	if err != nil {
-		color.Red("request failed: %v", err)
-		return nil, -1, nil, fmt.Errorf("request failed: %w", err)
+		return nil, -1, nil, requestError(req, err)
 	}
 	defer response.Body.Close()
 
 	resBody, ioErr := io.ReadAll(response.Body)
 	if ioErr != nil {
-		color.Red("io error: %v", ioErr)
-		return nil, response.StatusCode, response.Header, fmt.Errorf("failed to read response: %w", ioErr)
+		return nil, response.StatusCode, response.Header, requestError(req, ioErr)
 	}
 	if response.StatusCode >= 400 {
-		return resBody, response.StatusCode, response.Header, fmt.Errorf("request failed with status code: %d", response.StatusCode)
+		return resBody, response.StatusCode, response.Header, responseError(req, response, resBody)
 	}
 	return resBody, response.StatusCode, response.Header, nil
 }
 
+// postReq sends a form POST with the crumb of the account's session. Redirects are
+// not followed because Jenkins answers most actions with a 302.
+func postReq(cfg config.JenkinsConfig, api string, form url.Values) ([]byte, int, http.Header, error) {
+	return postBody(cfg, api, strings.NewReader(form.Encode()), "application/x-www-form-urlencoded")
+}
+
+// postBody is postReq for raw request bodies such as config.xml documents. A POST
+// refused for its crumb is sent once more with a fresh crumb, the session may have
+// expired or Jenkins restarted since the crumb was issued.
+func postBody(cfg config.JenkinsConfig, api string, body io.Reader, contentType string) ([]byte, int, http.Header, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, -1, nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	session := sessionFor(cfg)
+	for attempt := 0; ; attempt++ {
+		crumbRequestField, crumb, err := session.crumbFor(cfg, attempt > 0)
+		if err != nil {
+			return nil, -1, nil, err
+		}
+		req, err := http.NewRequest("POST", strings.TrimSuffix(cfg.BaseApi, "/")+api, bytes.NewReader(data))
+		if err != nil {
+			return nil, -1, nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if err := buildRequest(cfg, req); err != nil {
+			return nil, -1, nil, err
+		}
+		req.Header.Add("Content-Type", contentType)
+		if crumbRequestField != "" {
+			req.Header.Add(crumbRequestField, crumb)
+		}
+
+		resBody, statusCode, header, err := doReq(httpClient(cfg, false), req)
+		var crumbErr *CrumbError
+		if attempt == 0 && errors.As(err, &crumbErr) {
+			continue
+		}
+		return resBody, statusCode, header, err
+	}
+}
+
+// GetViews returns the full names of every view, including nested views and the
+// views of folders, each view before its nested views.
 func GetViews(cfg config.JenkinsConfig) ([]string, error) {
-	resBody, _, _, err := baseReq(cfg, "/api/json", make(map[string]string))
+	tree := NewTree().Nested("views", viewNameTree(maxViewDepth)).Nested("jobs", folderTree(maxFolderDepth, viewNameTree(maxViewDepth)))
+	resBody, _, _, err := cachedReq(cfg, "/api/json", tree.Params())
 	if err != nil {
 		return nil, err
 	}
-	viewNames := gjson.Get(string(resBody), "views.#.name")
+	summaries := make([]config.ViewSummary, 0)
+	gjson.GetBytes(resBody, "views").ForEach(func(_, view gjson.Result) bool {
+		summaries = append(summaries, parseViewSummary(view, ""))
+		return true
+	})
+	summaries = append(summaries, parseFolderViews(gjson.GetBytes(resBody, "jobs"), "")...)
 	viewRes := make([]string, 0)
-	for _, viewName := range viewNames.Array() {
-		viewRes = append(viewRes, viewName.String())
+	var walk func(views []config.ViewSummary)
+	walk = func(views []config.ViewSummary) {
+		for _, view := range views {
+			viewRes = append(viewRes, view.Name)
+			walk(view.Views)
+		}
 	}
+	walk(summaries)
 	return viewRes, nil
 }
 
 func GetViewJob(cfg config.JenkinsConfig, viewName string) ([]string, error) {
-	resBody, _, _, err := baseReq(cfg, "/view/"+viewName+"/api/json", make(map[string]string))
+	resBody, _, _, err := cachedReq(cfg, ViewPath(viewName)+"/api/json", NewTree().Nested("jobs", NewTree("name", "fullName")).Params())
 	if err != nil {
 		return nil, err
 	}
-	jobNames := gjson.Get(string(resBody), "jobs.#.name")
 	jobRes := make([]string, 0)
-	for _, jobName := range jobNames.Array() {
-		jobRes = append(jobRes, jobName.String())
-	}
+	gjson.GetBytes(resBody, "jobs").ForEach(func(_, job gjson.Result) bool {
+		jobRes = append(jobRes, jobFullName(job))
+		return true
+	})
 	return jobRes, nil
 }
 
+// viewJobTree selects the fields of a job its change marker is derived from, which
+// include the branches of git parameters since new branches come without a build.
+// Changing it changes every marker and refetches every job once.
+var viewJobTree = NewTree("name", "fullName", "color", "nextBuildNumber").
+	Nested("property", NewTree().Nested("parameterDefinitions", NewTree("name", "type", "choices").
+		Nested("allValueItems", NewTree().Nested("values", NewTree("value")))))
+
+// maxViewDepth bounds how deep nested views are followed, the tree parameter has to
+// spell out every level.
+const maxViewDepth = 4
+
+// viewNameTree returns the tree query selecting the name of a view and of its
+// nested views.
+func viewNameTree(depth int) Tree {
+	tree := NewTree("name")
+	if depth > 1 {
+		tree = tree.Nested("views", viewNameTree(depth-1))
+	}
+	return tree
+}
+
+// viewTree returns the tree query selecting a view, its jobs and its nested views.
+func viewTree(depth int) Tree {
+	tree := NewTree("name").Nested("jobs", viewJobTree)
+	if depth > 1 {
+		tree = tree.Nested("views", viewTree(depth-1))
+	}
+	return tree
+}
+
+// FolderSeparator ends the folders part of the full name of a view belonging to a
+// folder, e.g. "platform » tools » backend/api" for the nested view api of the view
+// backend in the folder tools of the folder platform. Jenkins shows folders the same
+// way in its breadcrumbs.
+const FolderSeparator = " » "
+
+// maxFolderDepth bounds how deep folders are searched for views.
+const maxFolderDepth = 3
+
+// folderTree returns the tree query selecting the views of the folders among the
+// jobs of a folder or of Jenkins with the view tree views. Jobs which are not folders
+// only render their name.
+func folderTree(depth int, views Tree) Tree {
+	tree := NewTree("name").Nested("views", views)
+	if depth > 1 {
+		tree = tree.Nested("jobs", folderTree(depth-1, views))
+	}
+	return tree
+}
+
+// splitViewName splits the full name of a view into its folders, empty for a view
+// of Jenkins itself, and its view path.
+func splitViewName(viewName string) ([]string, string) {
+	index := strings.LastIndex(viewName, FolderSeparator)
+	if index < 0 {
+		return nil, viewName
+	}
+	return strings.Split(viewName[:index], FolderSeparator), viewName[index+len(FolderSeparator):]
+}
+
+// ViewSeparator joins the names of nested views into their view path, e.g.
+// "team/backend". Jenkins forbids "/" in the names of views and folders.
+const ViewSeparator = "/"
+
+// ViewBaseName returns the name of a view without its enclosing views and folders.
+func ViewBaseName(viewName string) string {
+	_, viewPath := splitViewName(viewName)
+	return viewPath[strings.LastIndex(viewPath, ViewSeparator)+1:]
+}
+
+// ViewParentName returns the full name of the view containing a nested view, or ""
+// for the top level views of Jenkins and of folders.
+func ViewParentName(viewName string) string {
+	index := strings.LastIndex(viewName, ViewSeparator)
+	if index < 0 {
+		return ""
+	}
+	return viewName[:index]
+}
+
+func folderPath(folders []string) string {
+	if len(folders) == 0 {
+		return ""
+	}
+	return JobPath(strings.Join(folders, "/"))
+}
+
+// JobPath returns the URL path of a job from its full name, the folders holding it
+// being separated by "/" as in the fullName Jenkins reports, e.g. "platform/api".
+func JobPath(jobName string) string {
+	var builder strings.Builder
+	for segment := range strings.SplitSeq(jobName, "/") {
+		builder.WriteString("/job/")
+		builder.WriteString(url.PathEscape(segment))
+	}
+	return builder.String()
+}
+
+// jobFullName returns the full name of a job, which differs from its name for the
+// jobs of folders. Trees that do not select fullName fall back to the name.
+func jobFullName(job gjson.Result) string {
+	if fullName := job.Get("fullName"); fullName.Exists() {
+		return fullName.String()
+	}
+	return job.Get("name").String()
+}
+
+// ViewPath returns the URL path of a view from its full name, nested views being
+// separated by "/" and preceded by the folders holding them, if any.
+func ViewPath(viewName string) string {
+	folders, viewPath := splitViewName(viewName)
+	var builder strings.Builder
+	builder.WriteString(folderPath(folders))
+	for segment := range strings.SplitSeq(strings.Trim(viewPath, ViewSeparator), ViewSeparator) {
+		builder.WriteString("/view/")
+		builder.WriteString(segment)
+	}
+	return builder.String()
+}
+
+// GetViewsWithJobs loads every view, including the views of folders, together with
+// its jobs in a single tree query. Each job carries a change marker derived from its
+// next build number and parameter definitions, so callers can skip refetching jobs
+// that did not change.
+func GetViewsWithJobs(cfg config.JenkinsConfig) ([]config.ViewSummary, error) {
+	tree := NewTree().Nested("views", viewTree(maxViewDepth)).Nested("jobs", folderTree(maxFolderDepth, viewTree(maxViewDepth)))
+	resBody, _, _, err := baseReq(cfg, "/api/json", tree.Params())
+	if err != nil {
+		return nil, err
+	}
+	views := gjson.GetBytes(resBody, "views")
+	if !views.IsArray() {
+		return nil, fmt.Errorf("tree query returned no views")
+	}
+	viewRes := make([]config.ViewSummary, 0)
+	views.ForEach(func(_, view gjson.Result) bool {
+		viewRes = append(viewRes, parseViewSummary(view, ""))
+		return true
+	})
+	return append(viewRes, parseFolderViews(gjson.GetBytes(resBody, "jobs"), "")...), nil
+}
+
+// parseFolderViews reads the views of the folders among jobs and their subfolders,
+// prefixing their names with the folders holding them.
+func parseFolderViews(jobs gjson.Result, prefix string) []config.ViewSummary {
+	viewRes := make([]config.ViewSummary, 0)
+	jobs.ForEach(func(_, job gjson.Result) bool {
+		folderPrefix := prefix + job.Get("name").String() + FolderSeparator
+		job.Get("views").ForEach(func(_, view gjson.Result) bool {
+			viewRes = append(viewRes, parseViewSummary(view, folderPrefix))
+			return true
+		})
+		viewRes = append(viewRes, parseFolderViews(job.Get("jobs"), folderPrefix)...)
+		return true
+	})
+	return viewRes
+}
+
+// GetViewWithJobs is GetViewsWithJobs for a single view, given by its full name.
+func GetViewWithJobs(cfg config.JenkinsConfig, viewName string) (config.ViewSummary, error) {
+	resBody, _, _, err := baseReq(cfg, ViewPath(viewName)+"/api/json", viewTree(maxViewDepth).Params())
+	if err != nil {
+		return config.ViewSummary{}, err
+	}
+	prefix := strings.TrimSuffix(viewName, ViewBaseName(viewName))
+	return parseViewSummary(gjson.ParseBytes(resBody), prefix), nil
+}
+
+// parseViewSummary reads a view and its nested views, prefixing their names with
+// prefix, the full name of the enclosing view or the folders holding the view.
+func parseViewSummary(view gjson.Result, prefix string) config.ViewSummary {
+	name := prefix + view.Get("name").String()
+	summary := config.ViewSummary{
+		Name: name,
+		Jobs: make([]config.JobSummary, 0),
+	}
+	view.Get("views").ForEach(func(_, nested gjson.Result) bool {
+		summary.Views = append(summary.Views, parseViewSummary(nested, name+ViewSeparator))
+		return true
+	})
+	view.Get("jobs").ForEach(func(_, job gjson.Result) bool {
+		summary.Jobs = append(summary.Jobs, config.JobSummary{
+			Name:   jobFullName(job),
+			Color:  job.Get("color").String(),
+			Marker: jobChangeMarker(job),
+		})
+		return true
+	})
+	return summary
+}
+
+// CreateListView creates an empty list view. A full name such as "team/backend"
+// creates the view inside an existing nested view, "platform » backend" inside a
+// folder.
+func CreateListView(cfg config.JenkinsConfig, viewName string) error {
+	name := ViewBaseName(viewName)
+	parentPath := ""
+	switch prefix := strings.TrimSuffix(viewName, name); {
+	case ViewParentName(viewName) != "":
+		parentPath = ViewPath(ViewParentName(viewName))
+	case prefix != "":
+		parentPath = folderPath(strings.Split(strings.TrimSuffix(prefix, FolderSeparator), FolderSeparator))
+	}
+	mode := "hudson.model.ListView"
+	descriptor, err := json.Marshal(map[string]string{"name": name, "mode": mode})
+	if err != nil {
+		return err
+	}
+	form := url.Values{}
+	form.Set("name", name)
+	form.Set("mode", mode)
+	form.Set("json", string(descriptor))
+	_, _, _, err = postReq(cfg, parentPath+"/createView", form)
+	return err
+}
+
+func AddJobToView(cfg config.JenkinsConfig, viewName string, jobName string) error {
+	_, _, _, err := postReq(cfg, ViewPath(viewName)+"/addJobToView?name="+url.QueryEscape(jobName), url.Values{})
+	return err
+}
+
+func RemoveJobFromView(cfg config.JenkinsConfig, viewName string, jobName string) error {
+	_, _, _, err := postReq(cfg, ViewPath(viewName)+"/removeJobFromView?name="+url.QueryEscape(jobName), url.Values{})
+	return err
+}
+
+func DeleteView(cfg config.JenkinsConfig, viewName string) error {
+	_, _, _, err := postReq(cfg, ViewPath(viewName)+"/doDelete", url.Values{})
+	return err
+}
+
+func GetViewConfig(cfg config.JenkinsConfig, viewName string) (string, error) {
+	resBody, _, _, err := baseReq(cfg, ViewPath(viewName)+"/config.xml", make(map[string]string))
+	if err != nil {
+		return "", err
+	}
+	return string(resBody), nil
+}
+
+func UpdateViewConfig(cfg config.JenkinsConfig, viewName string, configXml string) error {
+	_, _, _, err := postBody(cfg, ViewPath(viewName)+"/config.xml", strings.NewReader(configXml), "application/xml")
+	return err
+}
+
+func jobChangeMarker(job gjson.Result) string {
+	hash := sha1.New()
+	hash.Write([]byte(job.Get("nextBuildNumber").String()))
+	hash.Write([]byte(job.Get("property.#.parameterDefinitions").Raw))
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// jobParamsTree selects the choices and git branches of the parameter definitions,
+// Jenkins always renders their _class.
+var jobParamsTree = NewTree().Nested("property", NewTree().Nested("parameterDefinitions",
+	NewTree("choices").Nested("allValueItems", NewTree().Nested("values", NewTree("value")))))
+
 func GetJobParams(cfg config.JenkinsConfig, jobName string) ([]string, []string, error) {
-	resBody, _, _, err := baseReq(cfg, "/job/"+jobName+"/api/json", make(map[string]string))
+	resBody, _, _, err := cachedReq(cfg, JobPath(jobName)+"/api/json", jobParamsTree.Params())
 	if err != nil {
 		return nil, nil, err
 	}
@@ -109,61 +442,147 @@ func GetJobParams(cfg config.JenkinsConfig, jobName string) ([]string, []string,
 	return choicesArray, branchArray, nil
 }
 
-func GetCrumb(cfg config.JenkinsConfig) (string, string, error) {
-	resBody, _, _, err := baseReq(cfg, "/crumbIssuer/api/json", make(map[string]string))
+func GetJobConfig(cfg config.JenkinsConfig, jobName string) (string, error) {
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/config.xml", make(map[string]string))
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
-	resutArray := gjson.GetMany(string(resBody), "crumbRequestField", "crumb")
-	return resutArray[0].String(), resutArray[1].String(), nil
+	return string(resBody), nil
 }
 
-func BuildWithParameters(cfg config.JenkinsConfig, jobName string, choices string, branch string) (string, error) {
-	crumbRequestField, crumb, err := GetCrumb(cfg)
+func UpdateJobConfig(cfg config.JenkinsConfig, jobName string, configXml string) error {
+	_, _, _, err := postBody(cfg, JobPath(jobName)+"/config.xml", strings.NewReader(configXml), "application/xml")
+	return err
+}
+
+// CreateJob creates jobName from a config.xml document.
+func CreateJob(cfg config.JenkinsConfig, jobName string, configXml string) error {
+	_, _, _, err := postBody(cfg, "/createItem?name="+url.QueryEscape(jobName), strings.NewReader(configXml), "application/xml")
+	return err
+}
+
+// CopyJob creates jobName as a copy of fromJob.
+func CopyJob(cfg config.JenkinsConfig, fromJob string, jobName string) error {
+	query := url.Values{}
+	query.Set("name", jobName)
+	query.Set("mode", "copy")
+	query.Set("from", fromJob)
+	_, _, _, err := postReq(cfg, "/createItem?"+query.Encode(), url.Values{})
+	return err
+}
+
+func EnableJob(cfg config.JenkinsConfig, jobName string) error {
+	_, _, _, err := postReq(cfg, JobPath(jobName)+"/enable", url.Values{})
+	return err
+}
+
+func DisableJob(cfg config.JenkinsConfig, jobName string) error {
+	_, _, _, err := postReq(cfg, JobPath(jobName)+"/disable", url.Values{})
+	return err
+}
+
+func DeleteJob(cfg config.JenkinsConfig, jobName string) error {
+	_, _, _, err := postReq(cfg, JobPath(jobName)+"/doDelete", url.Values{})
+	return err
+}
+
+func RenameJob(cfg config.JenkinsConfig, jobName string, newName string) error {
+	form := url.Values{}
+	form.Set("newName", newName)
+	_, _, _, err := postReq(cfg, JobPath(jobName)+"/confirmRename", form)
+	return err
+}
+
+const gitParameterClass = "net.uaznia.lukanus.hudson.plugins.gitparameter.GitParameterDefinition"
+
+// RefreshGitBranches asks the git parameter plugin to list the remote branches again
+// instead of relying on the values cached when the job page was rendered. The
+// display name may carry extra details such as the last commit of a revision.
+func RefreshGitBranches(cfg config.JenkinsConfig, jobName string) ([]config.BranchItem, error) {
+	tree := NewTree().Nested("property", NewTree().Nested("parameterDefinitions", NewTree("name")))
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/api/json", tree.Params())
 	if err != nil {
-		return "", fmt.Errorf("failed to get crumb: %w", err)
+		return nil, err
 	}
-	if crumbRequestField == "" || crumb == "" {
-		return "", fmt.Errorf("received empty crumb from Jenkins")
+	paramName := gjson.GetBytes(resBody, `property.#.parameterDefinitions|@flatten|#(_class=="`+gitParameterClass+`").name`).String()
+	if paramName == "" {
+		return nil, fmt.Errorf("job %s has no git parameter", jobName)
 	}
 
-	data := url.Values{}
-	data.Set("tag", branch)
-	data.Set("pro", choices)
-	encodedData := data.Encode()
-
-	req, err := http.NewRequest("POST", cfg.BaseApi+"/job/"+jobName+"/buildWithParameters", strings.NewReader(encodedData))
+	form := url.Values{}
+	form.Set("param", paramName)
+	resBody, _, _, err = postReq(cfg, JobPath(jobName)+"/descriptorByName/"+gitParameterClass+"/fillValueItems", form)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
+	items := make([]config.BranchItem, 0)
+	gjson.GetBytes(resBody, "values").ForEach(func(_, item gjson.Result) bool {
+		value := item.Get("value").String()
+		if value != "" {
+			items = append(items, config.BranchItem{Value: value, Name: item.Get("name").String()})
+		}
+		return true
+	})
+	return items, nil
+}
 
-	auth := cfg.Username + ":" + cfg.Token
-	encodedAuth := base64.StdEncoding.EncodeToString([]byte(auth))
-	req.Header.Add("Authorization", "Basic "+encodedAuth)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Add(crumbRequestField, crumb)
+var scmUrlPattern = regexp.MustCompile(`<(?:url|remote)>([^<]+)</(?:url|remote)>`)
 
-	response, err := http.DefaultClient.Do(req)
+// GetJobSCMURLs extracts the repository URLs referenced by the job configuration,
+// covering freestyle SCM settings as well as pipeline definitions from SCM.
+func GetJobSCMURLs(cfg config.JenkinsConfig, jobName string) ([]string, error) {
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/config.xml", make(map[string]string))
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
-	defer response.Body.Close()
-
-	if response.StatusCode == 201 {
-		location := response.Header.Get("Location")
-		u, err := url.Parse(location)
-		if err != nil {
-			return "", fmt.Errorf("failed to parse response location: %w", err)
+	urls := make([]string, 0)
+	for _, match := range scmUrlPattern.FindAllStringSubmatch(string(resBody), -1) {
+		value := html.UnescapeString(strings.TrimSpace(match[1]))
+		if value != "" && !slices.Contains(urls, value) {
+			urls = append(urls, value)
 		}
-		lastPart := path.Base(u.Path)
-		return lastPart, nil
-	} else {
-		return "", fmt.Errorf("build failed with status code: %d", response.StatusCode)
 	}
+	return urls, nil
+}
+
+func GetCrumb(cfg config.JenkinsConfig) (string, string, error) {
+	resBody, _, _, err := cachedReq(cfg, "/crumbIssuer/api/json", NewTree("crumbRequestField", "crumb").Params())
+	if err != nil {
+		return "", "", err
+	}
+	resutArray := gjson.GetMany(string(resBody), "crumbRequestField", "crumb")
+	return resutArray[0].String(), resutArray[1].String(), nil
+}
+
+func BuildWithParameters(cfg config.JenkinsConfig, jobName string, choices string, branch string) (string, error) {
+	return BuildWithParams(cfg, jobName, map[string]string{
+		config.PARAM_BRANCH: branch,
+		config.PARAM_CHOICE: choices,
+	})
+}
+
+// BuildWithParams triggers jobName with an arbitrary parameter set and returns the queue id.
+func BuildWithParams(cfg config.JenkinsConfig, jobName string, params map[string]string) (string, error) {
+	data := url.Values{}
+	for key, value := range params {
+		data.Set(key, value)
+	}
+	_, statusCode, header, err := postReq(cfg, JobPath(jobName)+"/buildWithParameters", data)
+	if err != nil {
+		return "", err
+	}
+	if statusCode != 201 {
+		return "", fmt.Errorf("build failed with status code: %d", statusCode)
+	}
+	u, err := url.Parse(header.Get("Location"))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse response location: %w", err)
+	}
+	return path.Base(u.Path), nil
 }
 
 func GetBuildNumber(cfg config.JenkinsConfig, queueId string) (string, error) {
-	resBody, _, _, err := baseReq(cfg, "/queue/item/"+queueId+"/api/json", make(map[string]string))
+	resBody, _, _, err := baseReq(cfg, "/queue/item/"+queueId+"/api/json", NewTree().Nested("executable", NewTree("number")).Params())
 	if err != nil {
 		return "", err
 	}
@@ -171,7 +590,7 @@ func GetBuildNumber(cfg config.JenkinsConfig, queueId string) (string, error) {
 }
 
 func GetBuildLog(cfg config.JenkinsConfig, jobName string, buildNumber string) (string, error) {
-	resBody, _, _, err := baseReq(cfg, "/job/"+jobName+"/"+buildNumber+"/logText/progressiveText/api/json", make(map[string]string))
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/"+buildNumber+"/logText/progressiveText/api/json", make(map[string]string))
 	if err != nil {
 		return "", err
 	}
@@ -179,8 +598,13 @@ func GetBuildLog(cfg config.JenkinsConfig, jobName string, buildNumber string) (
 	return log, nil
 }
 
+var queueTree = NewTree().Nested("items", NewTree("id").
+	Nested("task", NewTree("name", "fullName")).
+	Fields("params", "why", "blocked", "stuck", "inQueueSince").
+	Nested("actions", NewTree().Nested("causes", buildCauseTree)))
+
 func GetQueue(cfg config.JenkinsConfig) ([]config.Queue, error) {
-	resBody, _, _, err := baseReq(cfg, "/queue/api/json", make(map[string]string))
+	resBody, _, _, err := baseReq(cfg, "/queue/api/json", queueTree.Params())
 	if err != nil {
 		return nil, err
 	}
@@ -189,20 +613,30 @@ func GetQueue(cfg config.JenkinsConfig) ([]config.Queue, error) {
 	queueArray := make([]config.Queue, 0)
 	for _, item := range items.Array() {
 		var queueItem config.Queue
-		queueItem.Id = item.Get("id").Str
-		queueItem.TaskName = item.Get("task.name").Str
+		queueItem.Id = item.Get("id").String()
+		queueItem.TaskName = jobFullName(item.Get("task"))
 		queueItem.Params = item.Get("params").Str
 		queueItem.Why = item.Get("why").Str
 		queueItem.Blocked = item.Get("blocked").Bool()
 		queueItem.Stuck = item.Get("stuck").Bool()
-		queueItem.InQueueSince = item.Get("inQueueSince").Str
+		queueItem.InQueueSince = item.Get("inQueueSince").String()
+		queueItem.Causes = make([]config.BuildCause, 0)
+		item.Get("actions.#.causes|@flatten").ForEach(func(_, cause gjson.Result) bool {
+			queueItem.Causes = append(queueItem.Causes, parseBuildCause(cause))
+			return true
+		})
 		queueArray = append(queueArray, queueItem)
 	}
 	return queueArray, nil
 }
 
+// computerTree reaches the running builds without the depth=1 rendering of every
+// executor and node monitor.
+var computerTree = NewTree().Nested("computer", NewTree().
+	Nested("oneOffExecutors", NewTree().Nested("currentExecutable", NewTree("number", "url"))))
+
 func GetComputer(cfg config.JenkinsConfig) ([]config.Computer, error) {
-	resBody, _, _, err := baseReq(cfg, "/computer/api/json", map[string]string{"depth": "1"})
+	resBody, _, _, err := baseReq(cfg, "/computer/api/json", computerTree.Params())
 	if err != nil {
 		return nil, err
 	}
@@ -230,53 +664,283 @@ func GetComputer(cfg config.JenkinsConfig) ([]config.Computer, error) {
 	return computerArray, nil
 }
 
+var buildStatusTree = jobStatusBuildTree.
+	Fields("queueId", "fullDisplayName", "builtOn", "description", "url").
+	Nested("actions", NewTree().
+		Nested("causes", buildCauseTree).
+		Nested("parameters", NewTree("name", "value"))).
+	Nested("changeSets", changeSetsTree)
+
+// GetBuildStatus returns everything the status of a single build shows. buildNumber
+// may also be a permalink such as lastBuild.
 func GetBuildStatus(cfg config.JenkinsConfig, jobName string, buildNumber string) (config.BuildInfo, error) {
-	resBody, _, _, err := baseReq(cfg, "/job/"+jobName+"/"+buildNumber+"/api/json", make(map[string]string))
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/"+buildNumber+"/api/json", buildStatusTree.Params())
 	if err != nil {
 		return config.BuildInfo{}, err
 	}
-	res := gjson.GetMany(string(resBody), "queueId", "number", "building", "duration", "fullDisplayName", "changeSets")
+	build := gjson.ParseBytes(resBody)
 	buildStatus := config.BuildInfo{
-		QueueId:         res[0].String(),
-		BuildNumber:     res[1].String(),
-		Building:        res[2].Bool(),
-		Duration:        int(res[3].Int()),
-		FullDisplayName: res[4].String(),
+		QueueId:           build.Get("queueId").String(),
+		BuildNumber:       build.Get("number").String(),
+		Building:          build.Get("building").Bool(),
+		Duration:          int(build.Get("duration").Int()),
+		FullDisplayName:   build.Get("fullDisplayName").String(),
+		Result:            build.Get("result").String(),
+		Timestamp:         build.Get("timestamp").Int(),
+		EstimatedDuration: build.Get("estimatedDuration").Int(),
+		BuiltOn:           build.Get("builtOn").String(),
+		Description:       build.Get("description").String(),
+		Url:               build.Get("url").String(),
+		Parameters:        parseBuildParameters(build),
+		Causes:            make([]config.BuildCause, 0),
+		ChangeSets:        parseChangeSets(build.Get("changeSets")),
+	}
+	build.Get("actions.#.causes|@flatten").ForEach(func(_, cause gjson.Result) bool {
+		buildStatus.Causes = append(buildStatus.Causes, parseBuildCause(cause))
+		return true
+	})
+	return buildStatus, nil
+}
+
+var buildCauseTree = NewTree("shortDescription", "upstreamProject", "upstreamBuild", "userId", "userName")
+
+var buildSummaryTree = jobStatusBuildTree.Nested("actions", NewTree().Nested("causes", buildCauseTree))
+
+// GetBuildSummary returns the result, timing and causes of a build.
+func GetBuildSummary(cfg config.JenkinsConfig, jobName string, buildNumber string) (config.BuildSummary, error) {
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/"+buildNumber+"/api/json", buildSummaryTree.Params())
+	if err != nil {
+		return config.BuildSummary{}, err
+	}
+	return parseBuildSummary(jobName, gjson.ParseBytes(resBody)), nil
+}
+
+// buildRefs are the symbolic build references accepted in place of a build number,
+// with the permalinks Jenkins resolves them through.
+var buildRefs = [][2]string{
+	{"last", "lastBuild"},
+	{"lastSuccessful", "lastSuccessfulBuild"},
+	{"lastFailed", "lastFailedBuild"},
+	{"lastStable", "lastStableBuild"},
+	{"lastUnstable", "lastUnstableBuild"},
+	{"lastUnsuccessful", "lastUnsuccessfulBuild"},
+	{"lastCompleted", "lastCompletedBuild"},
+}
+
+// BuildRefs lists the symbolic build references, e.g. for help texts.
+func BuildRefs() []string {
+	refs := make([]string, 0, len(buildRefs))
+	for _, ref := range buildRefs {
+		refs = append(refs, ref[0])
+	}
+	return refs
+}
+
+// BuildPermalink returns the permalink of a symbolic build reference such as
+// "lastFailed", the permalink itself being accepted as well.
+func BuildPermalink(ref string) (string, bool) {
+	for _, known := range buildRefs {
+		if ref == known[0] || ref == known[1] {
+			return known[1], true
+		}
+	}
+	return "", false
+}
+
+// ResolveBuildNumber returns the number of the build a build number or symbolic
+// reference designates. Resolving once keeps a command on the same build while
+// newer builds start.
+func ResolveBuildNumber(cfg config.JenkinsConfig, jobName string, ref string) (string, error) {
+	if _, err := strconv.Atoi(ref); err == nil {
+		return ref, nil
+	}
+	permalink, ok := BuildPermalink(ref)
+	if !ok {
+		return "", fmt.Errorf("invalid build reference %q", ref)
+	}
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/"+permalink+"/api/json", NewTree("number").Params())
+	if err != nil {
+		return "", err
+	}
+	return gjson.GetBytes(resBody, "number").String(), nil
+}
+
+// GetRecentBuilds returns the summaries of the latest limit builds of a job.
+func GetRecentBuilds(cfg config.JenkinsConfig, jobName string, limit int) ([]config.BuildSummary, error) {
+	tree := NewTree().Range("builds", buildSummaryTree, 0, limit)
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/api/json", tree.Params())
+	if err != nil {
+		return nil, err
+	}
+	builds := make([]config.BuildSummary, 0)
+	gjson.GetBytes(resBody, "builds").ForEach(func(_, build gjson.Result) bool {
+		builds = append(builds, parseBuildSummary(jobName, build))
+		return true
+	})
+	return builds, nil
+}
+
+// GetDownstreamProjects returns the jobs configured to run after jobName.
+func GetDownstreamProjects(cfg config.JenkinsConfig, jobName string) ([]string, error) {
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/api/json", NewTree().Nested("downstreamProjects", NewTree("name", "fullName")).Params())
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0)
+	gjson.GetBytes(resBody, "downstreamProjects").ForEach(func(_, job gjson.Result) bool {
+		names = append(names, jobFullName(job))
+		return true
+	})
+	return names, nil
+}
+
+var jobStatusBuildTree = NewTree("number", "result", "building", "duration", "estimatedDuration", "timestamp")
+
+var viewStatusTree = NewTree().Nested("jobs", NewTree("name", "fullName", "color").
+	Nested("healthReport", NewTree("score", "description")).
+	Nested("lastBuild", jobStatusBuildTree).
+	Nested("lastSuccessfulBuild", jobStatusBuildTree).
+	Nested("lastFailedBuild", jobStatusBuildTree))
+
+// GetViewStatus returns the dashboard state of every job of a view in one request.
+func GetViewStatus(cfg config.JenkinsConfig, viewName string) ([]config.JobStatus, error) {
+	resBody, _, _, err := baseReq(cfg, ViewPath(viewName)+"/api/json", viewStatusTree.Params())
+	if err != nil {
+		return nil, err
 	}
+	statuses := make([]config.JobStatus, 0)
+	gjson.GetBytes(resBody, "jobs").ForEach(func(_, job gjson.Result) bool {
+		name := jobFullName(job)
+		status := config.JobStatus{
+			Name:                name,
+			Color:               job.Get("color").String(),
+			HealthScore:         -1,
+			LastBuild:           parseBuildSummary(name, job.Get("lastBuild")),
+			LastSuccessfulBuild: parseBuildSummary(name, job.Get("lastSuccessfulBuild")),
+			LastFailedBuild:     parseBuildSummary(name, job.Get("lastFailedBuild")),
+		}
+		// Jenkins reports one score per metric, the job is as healthy as the worst.
+		job.Get("healthReport").ForEach(func(_, report gjson.Result) bool {
+			score := int(report.Get("score").Int())
+			if status.HealthScore < 0 || score < status.HealthScore {
+				status.HealthScore = score
+				status.HealthDescription = report.Get("description").String()
+			}
+			return true
+		})
+		statuses = append(statuses, status)
+		return true
+	})
+	return statuses, nil
+}
 
+func parseBuildSummary(jobName string, build gjson.Result) config.BuildSummary {
+	summary := config.BuildSummary{
+		JobName:           jobName,
+		Number:            int(build.Get("number").Int()),
+		Result:            build.Get("result").String(),
+		Building:          build.Get("building").Bool(),
+		Duration:          build.Get("duration").Int(),
+		EstimatedDuration: build.Get("estimatedDuration").Int(),
+		Timestamp:         build.Get("timestamp").Int(),
+		Causes:            make([]config.BuildCause, 0),
+	}
+	build.Get("actions.#.causes|@flatten").ForEach(func(_, cause gjson.Result) bool {
+		summary.Causes = append(summary.Causes, parseBuildCause(cause))
+		return true
+	})
+	return summary
+}
+
+func parseBuildCause(cause gjson.Result) config.BuildCause {
+	buildCause := config.BuildCause{
+		Type:             config.CAUSE_OTHER,
+		ShortDescription: cause.Get("shortDescription").String(),
+	}
+	// Besides hudson.model.Cause$UpstreamCause, subclasses such as the cause of the
+	// pipeline build step report an upstream build, recognised by its fields.
+	switch class := cause.Get("_class").String(); {
+	case cause.Get("upstreamProject").Exists() && cause.Get("upstreamBuild").Exists():
+		buildCause.Type = config.CAUSE_UPSTREAM
+		buildCause.UpstreamProject = cause.Get("upstreamProject").String()
+		buildCause.UpstreamBuild = int(cause.Get("upstreamBuild").Int())
+	case class == "hudson.model.Cause$UserIdCause":
+		buildCause.Type = config.CAUSE_USER
+		buildCause.UserId = cause.Get("userId").String()
+		buildCause.UserName = cause.Get("userName").String()
+	case class == "hudson.triggers.SCMTrigger$SCMTriggerCause":
+		buildCause.Type = config.CAUSE_SCM
+	case class == "hudson.triggers.TimerTrigger$TimerTriggerCause":
+		buildCause.Type = config.CAUSE_TIMER
+	}
+	return buildCause
+}
+
+// GetBuildChangeSets returns the commits recorded for a build.
+func GetBuildChangeSets(cfg config.JenkinsConfig, jobName string, buildNumber string) ([]config.ChangeSet, error) {
+	tree := NewTree().Nested("changeSets", changeSetsTree)
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/"+buildNumber+"/api/json", tree.Params())
+	if err != nil {
+		return nil, err
+	}
+	return parseChangeSets(gjson.GetBytes(resBody, "changeSets")), nil
+}
+
+var changeSetsTree = NewTree().Nested("items", NewTree("commitId", "timestamp", "comment").
+	Nested("author", NewTree("fullName")).
+	Fields("affectedPaths"))
+
+func parseChangeSets(changeSetArray gjson.Result) []config.ChangeSet {
 	changeSets := make([]config.ChangeSet, 0)
-	for _, changeSetItem := range res[5].Array() {
+	for _, changeSetItem := range changeSetArray.Array() {
 		changeSetItem.Get("items").ForEach(func(_, item gjson.Result) bool {
 			// 将提交信息中的换行符替换为空格
 			comment := strings.ReplaceAll(item.Get("comment").String(), "\n", " ")
 			comment = strings.ReplaceAll(comment, "\r", "")
+			paths := make([]string, 0)
+			for _, affectedPath := range item.Get("affectedPaths").Array() {
+				paths = append(paths, affectedPath.String())
+			}
 			changeSet := config.ChangeSet{
 				CommitId:       item.Get("commitId").String(),
 				Timestamp:      item.Get("timestamp").String(),
 				AuthorFullName: item.Get("author.fullName").String(),
 				Comment:        strings.TrimSpace(comment),
+				AffectedPaths:  paths,
 			}
 			changeSets = append(changeSets, changeSet)
 			return true
 		})
 	}
-	buildStatus.ChangeSets = changeSets
-	return buildStatus, nil
+	return changeSets
+}
+
+// GetBuildParameters returns the parameter values a build was started with.
+func GetBuildParameters(cfg config.JenkinsConfig, jobName string, buildNumber string) (map[string]string, error) {
+	tree := NewTree().Nested("actions", NewTree().Nested("parameters", NewTree("name", "value")))
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/"+buildNumber+"/api/json", tree.Params())
+	if err != nil {
+		return nil, err
+	}
+	return parseBuildParameters(gjson.ParseBytes(resBody)), nil
+}
+
+func parseBuildParameters(build gjson.Result) map[string]string {
+	values := make(map[string]string)
+	build.Get("actions.#.parameters|@flatten").ForEach(func(_, param gjson.Result) bool {
+		values[param.Get("name").String()] = param.Get("value").String()
+		return true
+	})
+	return values
 }
 
 func GetTextLog(cfg config.JenkinsConfig, jobName string, buildNumber string, start *int) (string, bool, int, error) {
-	reqUrl := "/job/" + url.PathEscape(jobName) + "/" + url.PathEscape(buildNumber) + "/logText/progressiveText"
+	reqUrl := JobPath(jobName) + "/" + url.PathEscape(buildNumber) + "/logText/progressiveText"
+	params := make(map[string]string)
 	if start != nil {
-		u, err := url.Parse(reqUrl)
-		if err != nil {
-			return "", false, -1, fmt.Errorf("failed to parse URL: %w", err)
-		}
-		q := u.Query()
-		q.Set("start", strconv.Itoa(*start))
-		u.RawQuery = q.Encode()
-		reqUrl = u.String()
+		params["start"] = strconv.Itoa(*start)
 	}
-	resBody, _, resHeader, err := baseReq(cfg, reqUrl, make(map[string]string))
+	resBody, _, resHeader, err := baseReq(cfg, reqUrl, params)
 	if err != nil {
 		return "", false, -1, err
 	}
@@ -303,7 +967,7 @@ func GetTextLog(cfg config.JenkinsConfig, jobName string, buildNumber string, st
 }
 
 func GetPipelineConfig(cfg config.JenkinsConfig, jobName string) (config.PipelineConfig, error) {
-	resBody, _, _, err := baseReq(cfg, "/job/"+jobName+"/wfapi/runs", make(map[string]string))
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/wfapi/runs", make(map[string]string))
 	if err != nil {
 		return config.PipelineConfig{}, err
 	}
@@ -332,7 +996,7 @@ func GetPipelineConfig(cfg config.JenkinsConfig, jobName string) (config.Pipelin
 }
 
 func GetWFDescribe(cfg config.JenkinsConfig, jobName string, buildNumber string) (config.WFDescribe, error) {
-	resBody, _, _, err := baseReq(cfg, "/job/"+jobName+"/"+buildNumber+"/wfapi/describe", make(map[string]string))
+	resBody, _, _, err := baseReq(cfg, JobPath(jobName)+"/"+buildNumber+"/wfapi/describe", make(map[string]string))
 	if err != nil {
 		return config.WFDescribe{}, err
 	}
@@ -359,45 +1023,27 @@ func GetWFDescribe(cfg config.JenkinsConfig, jobName string, buildNumber string)
 }
 
 func Stop(cfg config.JenkinsConfig, jobName string, buildNumber string) (bool, error) {
-	req, err := http.NewRequest("POST", cfg.BaseApi+"/job/"+jobName+"/"+buildNumber+"/stop", nil)
+	// Jenkins stop commonly returns 302 after accepting the request.
+	_, statusCode, _, err := postReq(cfg, JobPath(jobName)+"/"+buildNumber+"/stop", url.Values{})
 	if err != nil {
-		return false, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	if err := buildRequest(cfg, req); err != nil {
 		return false, err
 	}
-
-	client := &http.Client{
-		// Jenkins stop commonly returns 302 after accepting the request.
-		// Keep the first response instead of following a relative redirect.
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		},
-	}
-
-	response, err := client.Do(req)
-	if err != nil {
-		color.Red("request failed: %v", err)
-		return false, fmt.Errorf("request failed: %w", err)
-	}
-	defer response.Body.Close()
-
-	if response.StatusCode == 200 || response.StatusCode == 302 {
+	if statusCode == 200 || statusCode == 302 {
 		return true, nil
 	}
-	return false, fmt.Errorf("stop request failed with status code: %d", response.StatusCode)
+	return false, fmt.Errorf("stop request failed with status code: %d", statusCode)
 }
 
 func CancelItem(cfg config.JenkinsConfig, queueId string) (bool, error) {
 	if queueId == "" {
 		return false, fmt.Errorf("queue ID cannot be empty")
 	}
-	_, statusCode, _, err := baseReq(cfg, "/queue/cancelItem?id="+queueId, make(map[string]string))
+	_, statusCode, _, err := postReq(cfg, "/queue/cancelItem?id="+url.QueryEscape(queueId), url.Values{})
 	if err != nil {
 		return false, err
 	}
-	if statusCode == 200 || statusCode == 302 {
+	// Depending on its version Jenkins answers with no content or a redirect.
+	if statusCode == 200 || statusCode == 204 || statusCode == 302 {
 		return true, nil
 	}
 	return false, fmt.Errorf("cancel request failed with status code: %d", statusCode)
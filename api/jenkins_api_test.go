@@ -0,0 +1,715 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+// newTestServer starts a fake Jenkins with three jobs, the "all" view, a "team"
+// view nesting "team/backend", and returns an account pointing at it.
+func newTestServer(t *testing.T) (*jenkinstest.Server, config.JenkinsConfig) {
+	t.Helper()
+	server := jenkinstest.NewServer()
+	server.Username, server.Token = "tester", "secret"
+	t.Cleanup(server.Close)
+
+	server.AddJob(&jenkinstest.Job{
+		Name:     "svc-api",
+		Choices:  []string{"dev", "prod"},
+		Branches: []string{"main", "release"},
+		Config:   "<project><scm><userRemoteConfigs><hudson.plugins.git.UserRemoteConfig><url>git@github.com:acme/svc-api.git</url></hudson.plugins.git.UserRemoteConfig></userRemoteConfigs></scm></project>",
+		Health:   []jenkinstest.HealthReport{{Score: 100, Description: "Build stability"}, {Score: 60, Description: "Test results"}},
+		Builds: []*jenkinstest.Build{
+			{Number: 1, Result: "SUCCESS", Duration: 61000, Timestamp: 1700000000000},
+			{Number: 2, Result: "FAILURE", Duration: 30000, Timestamp: 1700000100000},
+		},
+	})
+	server.AddJob(&jenkinstest.Job{Name: "svc-web", Downstream: []string{"infra"}})
+	server.AddJob(&jenkinstest.Job{Name: "infra"})
+	server.AddView("team")
+	server.AddView("team/backend", "svc-api")
+
+	account := config.JenkinsConfig{Name: "test", Username: "tester", Token: "secret", BaseApi: server.URL}
+	return server, account
+}
+
+func TestViewPath(t *testing.T) {
+	cases := map[string]string{
+		"all":            "/view/all",
+		"team/backend":   "/view/team/view/backend",
+		"/team/backend/": "/view/team/view/backend",
+		"a/b/c":          "/view/a/view/b/view/c",
+		// Views of folders are named after the folders holding them.
+		"platform » backend":             "/job/platform/view/backend",
+		"platform » tools » backend/api": "/job/platform/job/tools/view/backend/view/api",
+	}
+	for name, expected := range cases {
+		if got := ViewPath(name); got != expected {
+			t.Errorf("ViewPath(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+func TestViewNames(t *testing.T) {
+	cases := []struct{ name, base, parent string }{
+		{"all", "all", ""},
+		{"team/backend", "backend", "team"},
+		{"platform » backend", "backend", ""},
+		{"platform » tools » backend/api", "api", "platform » tools » backend"},
+	}
+	for _, c := range cases {
+		if base, parent := ViewBaseName(c.name), ViewParentName(c.name); base != c.base || parent != c.parent {
+			t.Errorf("ViewBaseName, ViewParentName(%q) = %q, %q, want %q, %q", c.name, base, parent, c.base, c.parent)
+		}
+	}
+}
+
+func TestViewTreeDepth(t *testing.T) {
+	if got := strings.Count(viewTree(1).String(), "views["); got != 0 {
+		t.Errorf("viewTree(1) nests %d views, want 0", got)
+	}
+	if got := strings.Count(viewTree(maxViewDepth).String(), "views["); got != maxViewDepth-1 {
+		t.Errorf("viewTree(%d) nests %d views, want %d", maxViewDepth, got, maxViewDepth-1)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	_, account := newTestServer(t)
+	account.Token = "wrong"
+	if _, err := GetViews(account); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("GetViews with a wrong token returned %v, want a 401 error", err)
+	}
+}
+
+func TestGetViewsAndJobs(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddFolder("platform")
+	server.AddView("platform » ops")
+	server.AddView("platform » ops/nightly", "infra")
+	views, err := GetViews(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(views, []string{"all", "team", "team/backend", "platform » ops", "platform » ops/nightly"}) {
+		t.Errorf("GetViews = %v", views)
+	}
+	jobs, err := GetViewJob(account, "team/backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(jobs, []string{"svc-api"}) {
+		t.Errorf("GetViewJob(team/backend) = %v", jobs)
+	}
+}
+
+func TestGetViewsWithJobsNested(t *testing.T) {
+	server, account := newTestServer(t)
+	summaries, err := GetViewsWithJobs(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summaries) != 2 || summaries[1].Name != "team" {
+		t.Fatalf("GetViewsWithJobs = %+v", summaries)
+	}
+	nested := summaries[1].Views
+	if len(nested) != 1 || nested[0].Name != "team/backend" || len(nested[0].Jobs) != 1 {
+		t.Fatalf("nested views = %+v", nested)
+	}
+	request := server.RequestsTo(http.MethodGet, "/api/json")
+	if len(request) != 1 || !strings.HasPrefix(request[0].Query.Get("tree"), "views[name,jobs[") {
+		t.Errorf("tree query not sent: %+v", request)
+	}
+
+	single, err := GetViewWithJobs(account, "team/backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if single.Name != "team/backend" || single.Jobs[0].Name != "svc-api" {
+		t.Errorf("GetViewWithJobs = %+v", single)
+	}
+}
+
+func TestFolderViews(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddFolder("platform")
+	server.AddFolder("platform » tools")
+	server.AddView("platform » backend", "svc-api")
+	server.AddView("platform » tools » ops", "infra")
+	server.AddView("platform » tools » ops/nightly", "svc-web")
+
+	summaries, err := GetViewsWithJobs(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make([]string, 0)
+	for _, summary := range summaries {
+		names = append(names, summary.Name)
+		for _, nested := range summary.Views {
+			names = append(names, nested.Name)
+		}
+	}
+	expected := []string{"all", "team", "team/backend", "platform » backend", "platform » tools » ops", "platform » tools » ops/nightly"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Fatalf("GetViewsWithJobs views = %v, want %v", names, expected)
+	}
+	if folderView := summaries[2]; len(folderView.Jobs) != 1 || folderView.Jobs[0].Name != "svc-api" || folderView.Jobs[0].Marker == "" {
+		t.Errorf("folder view jobs = %+v", folderView.Jobs)
+	}
+
+	single, err := GetViewWithJobs(account, "platform » tools » ops/nightly")
+	if err != nil || single.Name != "platform » tools » ops/nightly" || single.Jobs[0].Name != "svc-web" {
+		t.Errorf("GetViewWithJobs = %+v, %v", single, err)
+	}
+	if jobs, err := GetViewJob(account, "platform » backend"); err != nil || !reflect.DeepEqual(jobs, []string{"svc-api"}) {
+		t.Errorf("GetViewJob = %v, %v", jobs, err)
+	}
+
+	if err := CreateListView(account, "platform » tools » release"); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateListView(account, "platform » backend/canary"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddJobToView(account, "platform » tools » release", "svc-web"); err != nil {
+		t.Fatal(err)
+	}
+	if view := server.View("platform » tools » release"); view == nil || !reflect.DeepEqual(view.Jobs, []string{"svc-web"}) {
+		t.Errorf("folder view after create and add = %+v", view)
+	}
+	if server.View("platform » backend/canary") == nil {
+		t.Error("nested view not created in the folder view")
+	}
+	if err := DeleteView(account, "platform » tools » release"); err != nil || server.View("platform » tools » release") != nil {
+		t.Errorf("DeleteView = %v", err)
+	}
+}
+
+func TestFolderJobs(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddFolder("platform")
+	server.AddFolder("platform » tools")
+	server.AddJob(&jenkinstest.Job{Name: "platform/tools/deploy", Downstream: []string{"infra"}, Builds: []*jenkinstest.Build{{Number: 1, Result: "SUCCESS"}}})
+	server.AddView("platform » tools » ops", "platform/tools/deploy")
+
+	if path := JobPath("platform/tools/deploy"); path != "/job/platform/job/tools/job/deploy" {
+		t.Errorf("JobPath = %q", path)
+	}
+	view, err := GetViewWithJobs(account, "platform » tools » ops")
+	if err != nil || len(view.Jobs) != 1 || view.Jobs[0].Name != "platform/tools/deploy" {
+		t.Fatalf("GetViewWithJobs = %+v, %v", view, err)
+	}
+	if jobs, err := GetViewJob(account, "platform » tools » ops"); err != nil || !reflect.DeepEqual(jobs, []string{"platform/tools/deploy"}) {
+		t.Errorf("GetViewJob = %v, %v", jobs, err)
+	}
+	if jobs, err := GetViewJob(account, "all"); err != nil || slices.Contains(jobs, "platform/tools/deploy") {
+		t.Errorf("jobs of the all view = %v, %v", jobs, err)
+	}
+
+	queueURL, err := BuildWithParams(account, view.Jobs[0].Name, map[string]string{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queue, err := GetQueue(account); err != nil || len(queue) != 1 || queue[0].TaskName != "platform/tools/deploy" {
+		t.Errorf("GetQueue = %+v, %v", queue, err)
+	}
+	buildNumber, err := GetBuildNumber(account, queueURL)
+	if err != nil || buildNumber != "2" {
+		t.Fatalf("GetBuildNumber = %q, %v", buildNumber, err)
+	}
+	if status, err := GetBuildStatus(account, "platform/tools/deploy", buildNumber); err != nil || status.FullDisplayName != "platform » tools » deploy #2" {
+		t.Errorf("GetBuildStatus = %+v, %v", status, err)
+	}
+	if statuses, err := GetViewStatus(account, "platform » tools » ops"); err != nil || len(statuses) != 1 || statuses[0].Name != "platform/tools/deploy" {
+		t.Errorf("GetViewStatus = %+v, %v", statuses, err)
+	}
+}
+
+func TestJobChangeMarker(t *testing.T) {
+	server, account := newTestServer(t)
+	before, err := GetViewWithJobs(account, "team/backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unchanged, _ := GetViewWithJobs(account, "team/backend")
+	if unchanged.Jobs[0].Marker != before.Jobs[0].Marker {
+		t.Errorf("marker changed without a change of the job")
+	}
+	server.AddBuild("svc-api", &jenkinstest.Build{Result: "SUCCESS"})
+	after, _ := GetViewWithJobs(account, "team/backend")
+	if after.Jobs[0].Marker == before.Jobs[0].Marker {
+		t.Errorf("marker did not change after a new build")
+	}
+}
+
+func TestViewLifecycle(t *testing.T) {
+	server, account := newTestServer(t)
+	if err := CreateListView(account, "team/frontend"); err != nil {
+		t.Fatal(err)
+	}
+	if server.View("team/frontend") == nil {
+		t.Fatal("nested view was not created")
+	}
+	form := server.RequestsTo(http.MethodPost, "/view/team/createView")[0].Form
+	if form.Get("name") != "frontend" || form.Get("mode") != "hudson.model.ListView" {
+		t.Errorf("createView form = %v", form)
+	}
+
+	if err := AddJobToView(account, "team/frontend", "svc-web"); err != nil {
+		t.Fatal(err)
+	}
+	if err := AddJobToView(account, "team/frontend", "missing"); err == nil {
+		t.Error("adding an unknown job succeeded")
+	}
+	if jobs := server.View("team/frontend").Jobs; !reflect.DeepEqual(jobs, []string{"svc-web"}) {
+		t.Errorf("view jobs after add = %v", jobs)
+	}
+	if err := RemoveJobFromView(account, "team/frontend", "svc-web"); err != nil {
+		t.Fatal(err)
+	}
+	if jobs := server.View("team/frontend").Jobs; len(jobs) != 0 {
+		t.Errorf("view jobs after remove = %v", jobs)
+	}
+
+	configXml, err := GetViewConfig(account, "team/backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(configXml, "<string>svc-api</string>") {
+		t.Errorf("view config = %s", configXml)
+	}
+	updated := strings.Replace(configXml, "</jobNames>", "</jobNames>\n  <includeRegex>svc-.*</includeRegex>", 1)
+	if err := UpdateViewConfig(account, "team/backend", updated); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetViewConfig(account, "team/backend"); got != updated {
+		t.Errorf("view config after update = %s", got)
+	}
+
+	if err := DeleteView(account, "team/frontend"); err != nil {
+		t.Fatal(err)
+	}
+	if server.View("team/frontend") != nil {
+		t.Error("view was not deleted")
+	}
+}
+
+func TestGetJobParams(t *testing.T) {
+	_, account := newTestServer(t)
+	choices, branches, err := GetJobParams(account, "svc-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(choices, []string{"dev", "prod"}) || !reflect.DeepEqual(branches, []string{"main", "release"}) {
+		t.Errorf("GetJobParams = %v, %v", choices, branches)
+	}
+	choices, branches, err = GetJobParams(account, "infra")
+	if err != nil || len(choices) != 0 || len(branches) != 0 {
+		t.Errorf("GetJobParams of a job without parameters = %v, %v, %v", choices, branches, err)
+	}
+	if _, _, err := GetJobParams(account, "missing"); err == nil {
+		t.Error("GetJobParams of an unknown job succeeded")
+	}
+}
+
+func TestRefreshGitBranches(t *testing.T) {
+	server, account := newTestServer(t)
+	job := server.Job("svc-api")
+	server.Update(func() { job.Branches = append(job.Branches, "feature/x") })
+	items, err := RefreshGitBranches(account, "svc-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[2].Value != "feature/x" {
+		t.Errorf("RefreshGitBranches = %+v", items)
+	}
+	if _, err := RefreshGitBranches(account, "infra"); err == nil {
+		t.Error("RefreshGitBranches of a job without git parameter succeeded")
+	}
+}
+
+func TestJobConfig(t *testing.T) {
+	server, account := newTestServer(t)
+	urls, err := GetJobSCMURLs(account, "svc-api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(urls, []string{"git@github.com:acme/svc-api.git"}) {
+		t.Errorf("GetJobSCMURLs = %v", urls)
+	}
+
+	if err := UpdateJobConfig(account, "infra", "<project><disabled>true</disabled></project>"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := GetJobConfig(account, "infra"); got != "<project><disabled>true</disabled></project>" {
+		t.Errorf("GetJobConfig after update = %s", got)
+	}
+	request := server.RequestsTo(http.MethodPost, "/job/infra/config.xml")[0]
+	if request.Header["Content-Type"][0] != "application/xml" || request.Header[jenkinstest.DefaultCrumbField][0] != jenkinstest.DefaultCrumb {
+		t.Errorf("config.xml POST headers = %v", request.Header)
+	}
+}
+
+func TestCreateAndCopyJob(t *testing.T) {
+	server, account := newTestServer(t)
+	if err := CreateJob(account, "new-job", "<project/>"); err != nil {
+		t.Fatal(err)
+	}
+	if err := CreateJob(account, "new-job", "<project/>"); err == nil {
+		t.Error("creating an existing job succeeded")
+	}
+	if err := CopyJob(account, "svc-api", "svc-api-copy"); err != nil {
+		t.Fatal(err)
+	}
+	copied := server.Job("svc-api-copy")
+	if copied == nil || !reflect.DeepEqual(copied.Choices, []string{"dev", "prod"}) {
+		t.Fatalf("copied job = %+v", copied)
+	}
+	query := server.RequestsTo(http.MethodPost, "/createItem")[2].Query
+	if query.Get("mode") != "copy" || query.Get("from") != "svc-api" {
+		t.Errorf("copy query = %v", query)
+	}
+}
+
+func TestJobLifecycle(t *testing.T) {
+	server, account := newTestServer(t)
+	if err := DisableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	if !server.Job("infra").Disabled {
+		t.Error("job was not disabled")
+	}
+	if err := EnableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	if server.Job("infra").Disabled {
+		t.Error("job was not enabled")
+	}
+	if err := RenameJob(account, "svc-web", "web"); err != nil {
+		t.Fatal(err)
+	}
+	if server.Job("web") == nil || server.Job("svc-web") != nil {
+		t.Error("job was not renamed")
+	}
+	if err := DeleteJob(account, "web"); err != nil {
+		t.Fatal(err)
+	}
+	if server.Job("web") != nil || slices.Contains(server.View("all").Jobs, "web") {
+		t.Error("job was not deleted")
+	}
+	if err := DeleteJob(account, "web"); err == nil {
+		t.Error("deleting an unknown job succeeded")
+	}
+}
+
+func TestPostWithoutCrumbIssuer(t *testing.T) {
+	server, account := newTestServer(t)
+	server.Crumb = ""
+	if err := DisableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := server.RequestsTo(http.MethodPost, "/job/infra/disable")[0].Header[jenkinstest.DefaultCrumbField]; ok {
+		t.Error("crumb sent although the server has no crumb issuer")
+	}
+}
+
+func TestBuildWithParamsAndQueue(t *testing.T) {
+	server, account := newTestServer(t)
+	server.QueueDelay = 1
+	queueId, err := BuildWithParams(account, "svc-api", map[string]string{"pro": "dev", "tag": "main"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queueId != "1" {
+		t.Errorf("queue id = %q", queueId)
+	}
+	form := server.RequestsTo(http.MethodPost, "/job/svc-api/buildWithParameters")[0].Form
+	if form.Get("pro") != "dev" || form.Get("tag") != "main" {
+		t.Errorf("build form = %v", form)
+	}
+
+	queue, err := GetQueue(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queue) != 1 || queue[0].Id != "1" || queue[0].TaskName != "svc-api" || queue[0].InQueueSince == "" {
+		t.Fatalf("GetQueue = %+v", queue)
+	}
+
+	if number, err := GetBuildNumber(account, queueId); err != nil || number != "" {
+		t.Errorf("build number while queued = %q, %v", number, err)
+	}
+	number, err := GetBuildNumber(account, queueId)
+	if err != nil || number != "3" {
+		t.Fatalf("build number once started = %q, %v", number, err)
+	}
+	params, err := GetBuildParameters(account, "svc-api", number)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(params, map[string]string{"pro": "dev", "tag": "main"}) {
+		t.Errorf("GetBuildParameters = %v", params)
+	}
+
+	computers, err := GetComputer(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(computers) != 1 || computers[0].JobName != "svc-api" || computers[0].BuildNumber != 3 {
+		t.Errorf("GetComputer = %+v", computers)
+	}
+}
+
+func TestBuildWithParamsWithoutCrumbIssuer(t *testing.T) {
+	server, account := newTestServer(t)
+	server.Crumb = ""
+	if _, err := BuildWithParams(account, "svc-api", nil); err != nil {
+		t.Fatalf("BuildWithParams without crumb issuer = %v", err)
+	}
+	if len(server.Queue()) != 1 {
+		t.Error("build was not queued")
+	}
+}
+
+func TestCancelItem(t *testing.T) {
+	server, account := newTestServer(t)
+	item := server.Enqueue("infra", nil)
+	if ok, err := CancelItem(account, "1"); !ok || err != nil {
+		t.Fatalf("CancelItem = %v, %v", ok, err)
+	}
+	if !item.Cancelled || len(server.Queue()) != 0 {
+		t.Error("queue item was not cancelled")
+	}
+	if len(server.RequestsTo(http.MethodPost, "/queue/cancelItem")) != 1 {
+		t.Error("cancel was not a POST")
+	}
+	if _, err := CancelItem(account, ""); err == nil {
+		t.Error("CancelItem without id succeeded")
+	}
+	if _, err := CancelItem(account, "42"); err == nil {
+		t.Error("CancelItem of an unknown item succeeded")
+	}
+}
+
+func TestStop(t *testing.T) {
+	server, account := newTestServer(t)
+	build := server.AddBuild("svc-api", &jenkinstest.Build{Building: true})
+	if ok, err := Stop(account, "svc-api", "3"); !ok || err != nil {
+		t.Fatalf("Stop = %v, %v", ok, err)
+	}
+	if crumb := server.RequestsTo(http.MethodPost, "/job/svc-api/3/stop")[0].Header.Get(jenkinstest.DefaultCrumbField); crumb != jenkinstest.DefaultCrumb {
+		t.Errorf("stop sent crumb %q", crumb)
+	}
+	if build.Building || build.Result != "ABORTED" {
+		t.Errorf("stopped build = %+v", build)
+	}
+	if _, err := Stop(account, "svc-api", "9"); err == nil {
+		t.Error("stopping an unknown build succeeded")
+	}
+}
+
+func TestBuildSummaries(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddBuild("infra", &jenkinstest.Build{
+		Number: 7, Result: "SUCCESS",
+		Causes: []jenkinstest.Cause{{UpstreamProject: "svc-web", UpstreamBuild: 4}},
+	})
+
+	summary, err := GetBuildSummary(account, "svc-api", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Number != 2 || summary.Result != "FAILURE" || summary.Duration != 30000 || summary.JobName != "svc-api" {
+		t.Errorf("GetBuildSummary = %+v", summary)
+	}
+
+	upstream, _ := GetBuildSummary(account, "infra", "7")
+	if len(upstream.Causes) != 1 || upstream.Causes[0].Type != config.CAUSE_UPSTREAM ||
+		upstream.Causes[0].UpstreamProject != "svc-web" || upstream.Causes[0].UpstreamBuild != 4 {
+		t.Errorf("upstream causes = %+v", upstream.Causes)
+	}
+
+	recent, err := GetRecentBuilds(account, "svc-api", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recent) != 2 || recent[0].Number != 2 {
+		t.Errorf("GetRecentBuilds = %+v", recent)
+	}
+
+	downstream, err := GetDownstreamProjects(account, "svc-web")
+	if err != nil || !reflect.DeepEqual(downstream, []string{"infra"}) {
+		t.Errorf("GetDownstreamProjects = %v, %v", downstream, err)
+	}
+
+	status, err := GetBuildStatus(account, "svc-api", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.BuildNumber != "1" || status.FullDisplayName != "svc-api #1" || status.Building {
+		t.Errorf("GetBuildStatus = %+v", status)
+	}
+
+	server.AddBuild("svc-web", &jenkinstest.Build{
+		Number: 1, Result: "SUCCESS", BuiltOn: "agent-1", Description: "nightly",
+		Params: map[string]string{"pro": "dev"},
+		Causes: []jenkinstest.Cause{{UserId: "tester", UserName: "Tester"}},
+	})
+	detailed, err := GetBuildStatus(account, "svc-web", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detailed.Result != "SUCCESS" || detailed.BuiltOn != "agent-1" || detailed.Description != "nightly" ||
+		!reflect.DeepEqual(detailed.Parameters, map[string]string{"pro": "dev"}) ||
+		len(detailed.Causes) != 1 || detailed.Causes[0].UserId != "tester" {
+		t.Errorf("GetBuildStatus = %+v", detailed)
+	}
+}
+
+func TestResolveBuildNumber(t *testing.T) {
+	_, account := newTestServer(t)
+	cases := map[string]string{"1": "1", "last": "2", "lastSuccessful": "1", "lastFailed": "2", "lastCompleted": "2"}
+	for ref, expected := range cases {
+		if number, err := ResolveBuildNumber(account, "svc-api", ref); err != nil || number != expected {
+			t.Errorf("ResolveBuildNumber(%q) = %q, %v, want %q", ref, number, err, expected)
+		}
+	}
+	if _, err := ResolveBuildNumber(account, "svc-api", "latest"); err == nil {
+		t.Error("ResolveBuildNumber accepted an invalid reference")
+	}
+	var notFound *NotFoundError
+	if _, err := ResolveBuildNumber(account, "svc-api", "lastUnstable"); !errors.As(err, &notFound) {
+		t.Errorf("ResolveBuildNumber without unstable builds = %v", err)
+	}
+}
+
+func TestGetBuildChangeSets(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddBuild("svc-web", &jenkinstest.Build{
+		Number: 1, Result: "SUCCESS",
+		ChangeSets: []jenkinstest.Change{{
+			CommitId: "abcdef0123", Timestamp: 1700000000000, Comment: "Fix login\r\nfor real\n",
+			Author: "Alice", AffectedPaths: []string{"src/login.go"},
+		}},
+	})
+	changeSets, err := GetBuildChangeSets(account, "svc-web", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []config.ChangeSet{{
+		CommitId: "abcdef0123", Timestamp: "1700000000000", AuthorFullName: "Alice",
+		Comment: "Fix login for real", AffectedPaths: []string{"src/login.go"},
+	}}
+	if !reflect.DeepEqual(changeSets, expected) {
+		t.Errorf("GetBuildChangeSets = %+v", changeSets)
+	}
+}
+
+func TestGetViewStatus(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddBuild("svc-api", &jenkinstest.Build{Building: true, Timestamp: 1700000200000})
+	statuses, err := GetViewStatus(account, "team/backend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("GetViewStatus = %+v", statuses)
+	}
+	status := statuses[0]
+	if status.HealthScore != 60 || status.HealthDescription != "Test results" {
+		t.Errorf("health = %d %s, want the worst report", status.HealthScore, status.HealthDescription)
+	}
+	if !status.LastBuild.Building || status.LastBuild.Number != 3 ||
+		status.LastSuccessfulBuild.Number != 1 || status.LastFailedBuild.Number != 2 {
+		t.Errorf("builds = %+v", status)
+	}
+
+	statuses, _ = GetViewStatus(account, "all")
+	for _, status := range statuses {
+		if status.Name == "infra" && (status.HealthScore != -1 || status.LastBuild.Number != 0) {
+			t.Errorf("job without builds = %+v", status)
+		}
+	}
+}
+
+func TestGetTextLogProgressive(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddBuild("svc-api", &jenkinstest.Build{
+		Building:    true,
+		LogChunks:   []string{"Started\n", "Compiling\n", "Finished: SUCCESS\n"},
+		FinalResult: "SUCCESS",
+	})
+
+	var output strings.Builder
+	start := 0
+	for range 10 {
+		text, more, size, err := GetTextLog(account, "svc-api", "3", &start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output.WriteString(text)
+		start = size
+		if !more {
+			break
+		}
+	}
+	if output.String() != "Started\nCompiling\nFinished: SUCCESS\n" {
+		t.Errorf("streamed log = %q", output.String())
+	}
+	if build := server.Build("svc-api", 3); build.Building || build.Result != "SUCCESS" {
+		t.Errorf("build after the last chunk = %+v", build)
+	}
+
+	text, more, size, err := GetTextLog(account, "svc-api", "3", nil)
+	if err != nil || more || size != len(text) {
+		t.Errorf("full log = %q, %v, %d, %v", text, more, size, err)
+	}
+}
+
+func TestPipelineStages(t *testing.T) {
+	server, account := newTestServer(t)
+	stages := []jenkinstest.Stage{
+		{Id: "6", Name: "Checkout", Status: "SUCCESS", DurationMillis: 2000},
+		{Id: "12", Name: "Test", Status: "IN_PROGRESS", DurationMillis: 5000},
+	}
+	server.AddBuild("svc-web", &jenkinstest.Build{Number: 1, Result: "SUCCESS", Stages: stages[:1]})
+	server.AddBuild("svc-web", &jenkinstest.Build{Number: 2, Building: true, Stages: stages})
+
+	describe, err := GetWFDescribe(account, "svc-web", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if describe.Status != "IN_PROGRESS" || len(describe.Stages) != 2 || describe.Stages[1].Name != "Test" {
+		t.Errorf("GetWFDescribe = %+v", describe)
+	}
+
+	pipeline, err := GetPipelineConfig(account, "svc-web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pipeline.Stages) != 2 {
+		t.Errorf("GetPipelineConfig stages = %+v, want the distinct stages of all runs", pipeline.Stages)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	server, account := newTestServer(t)
+	server.Fail(http.MethodGet, "/queue/api/json", http.StatusServiceUnavailable, 1)
+	if _, err := GetQueue(account); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("GetQueue with a failing server = %v", err)
+	}
+	if _, err := GetQueue(account); err != nil {
+		t.Errorf("GetQueue after the failure = %v", err)
+	}
+
+	server.Close()
+	if _, err := GetViews(account); err == nil {
+		t.Error("GetViews against a closed server succeeded")
+	}
+}
@@ -0,0 +1,97 @@
+// Command demo serves a fake Jenkins with sample jobs, views and a running pipeline
+// so jenkins-cli can be tried without a Jenkins instance:
+//
+//	go run ./api/jenkinstest/demo -addr 127.0.0.1:18080
+//
+// then add an account with base_api http://127.0.0.1:18080 and any credentials.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+)
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:18080", "listen address")
+	flag.Parse()
+
+	server, err := jenkinstest.NewServerAt(*addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer server.Close()
+	seed(server)
+	fmt.Printf("fake Jenkins listening on %s, press Ctrl+C to stop\n", server.URL)
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	<-interrupt
+}
+
+func seed(server *jenkinstest.Server) {
+	now := time.Now()
+	ago := func(duration time.Duration) int64 { return now.Add(-duration).UnixMilli() }
+	stages := []jenkinstest.Stage{
+		{Id: "6", Name: "Checkout", Status: "SUCCESS", DurationMillis: 4000},
+		{Id: "14", Name: "Build", Status: "SUCCESS", DurationMillis: 52000},
+		{Id: "27", Name: "Deploy", Status: "SUCCESS", DurationMillis: 18000},
+	}
+	running := make([]jenkinstest.Stage, len(stages))
+	copy(running, stages)
+	running[2].Status = "IN_PROGRESS"
+
+	logChunks := make([]string, 0)
+	for step := range 40 {
+		logChunks = append(logChunks, fmt.Sprintf("[Pipeline] step %d\n", step+1))
+	}
+	logChunks = append(logChunks, "Finished: SUCCESS\n")
+
+	server.AddJob(&jenkinstest.Job{
+		Name:     "order-service",
+		Choices:  []string{"dev", "staging", "prod"},
+		Branches: []string{"main", "release/1.4", "feature/refunds"},
+		Health:   []jenkinstest.HealthReport{{Score: 80, Description: "Build stability: 1 out of the last 5 builds failed."}},
+		Builds: []*jenkinstest.Build{
+			{Number: 41, Result: "SUCCESS", Duration: 74000, Timestamp: ago(26 * time.Hour), Stages: stages,
+				Params:     map[string]string{"pro": "dev", "tag": "main"},
+				ChangeSets: []jenkinstest.Change{{CommitId: "9f2c1e4b7a", Timestamp: ago(27 * time.Hour), Comment: "Add refund endpoint", Author: "Dana", AffectedPaths: []string{"api/refund.go"}}}},
+			{Number: 42, Result: "FAILURE", Duration: 35000, Timestamp: ago(3 * time.Hour), Stages: stages[:2],
+				Params: map[string]string{"pro": "staging", "tag": "release/1.4"}},
+			{Number: 43, Building: true, EstimatedDuration: 80000, Timestamp: ago(time.Minute), Stages: running,
+				Params: map[string]string{"pro": "prod", "tag": "release/1.4"},
+				Log:    "Started by user demo\n", LogChunks: logChunks, FinalResult: "SUCCESS"},
+		},
+		OnStart: func(build *jenkinstest.Build) {
+			build.Stages = running
+			build.LogChunks = strings.SplitAfter("Started by user demo\nBuilding\nFinished: SUCCESS\n", "\n")
+			build.FinalResult = "SUCCESS"
+		},
+		Downstream: []string{"e2e-tests"},
+	})
+	server.AddJob(&jenkinstest.Job{
+		Name:     "web-frontend",
+		Choices:  []string{"dev", "prod"},
+		Branches: []string{"main", "develop"},
+		Health:   []jenkinstest.HealthReport{{Score: 100, Description: "Build stability: No recent builds failed."}},
+		Builds:   []*jenkinstest.Build{{Number: 118, Result: "SUCCESS", Duration: 142000, Timestamp: ago(50 * time.Minute)}},
+	})
+	server.AddJob(&jenkinstest.Job{
+		Name:   "e2e-tests",
+		Health: []jenkinstest.HealthReport{{Score: 40, Description: "Test Result: 12 tests failing out of 310."}},
+		Builds: []*jenkinstest.Build{{Number: 7, Result: "UNSTABLE", Duration: 610000, Timestamp: ago(26 * time.Hour),
+			Causes: []jenkinstest.Cause{{UpstreamProject: "order-service", UpstreamBuild: 41}}}},
+	})
+	server.AddJob(&jenkinstest.Job{Name: "nightly-backup", Color: "disabled", Disabled: true})
+	server.AddView("backend", "order-service", "e2e-tests")
+	server.AddView("frontend", "web-frontend")
+	server.AddView("ops")
+	server.AddView("ops/maintenance", "nightly-backup")
+	server.Enqueue("web-frontend", map[string]string{"pro": "prod", "tag": "main"}).StartAfter = 1 << 30
+}
@@ -0,0 +1,124 @@
+package jenkinstest
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// Job is a freestyle or pipeline job of the fake server. Choices and Branches become
+// a choice parameter named "pro" and a git parameter named "tag", the parameters the
+// interactive build flow understands. Name is the full name, the jobs of folders
+// being named like "platform/api" as Jenkins does.
+type Job struct {
+	Name            string
+	Color           string
+	Disabled        bool
+	NextBuildNumber int
+	Choices         []string
+	Branches        []string
+	Config          string
+	Downstream      []string
+	Health          []HealthReport
+	Builds          []*Build
+	// OnStart lets a scenario prepare a build started from the queue, e.g. give it
+	// log chunks to stream.
+	OnStart func(build *Build)
+}
+
+type HealthReport struct {
+	Score       int
+	Description string
+}
+
+// Build is a build of a fake job. A building build streams one of its LogChunks per
+// progressive log request and finishes with FinalResult once the last chunk has
+// been served, so a test can script a running build without timers.
+type Build struct {
+	Number            int
+	Result            string
+	Building          bool
+	Duration          int64
+	EstimatedDuration int64
+	Timestamp         int64
+	QueueId           int
+	Params            map[string]string
+	Causes            []Cause
+	ChangeSets        []Change
+	Log               string
+	LogChunks         []string
+	FinalResult       string
+	Stages            []Stage
+	BuiltOn           string
+	Description       string
+}
+
+// Cause is rendered with the Jenkins class matching its fields: an upstream cause
+// when UpstreamProject is set, a user cause when UserId is set. Class overrides the
+// class, e.g. with the upstream cause of the pipeline build step.
+type Cause struct {
+	Class            string
+	ShortDescription string
+	UpstreamProject  string
+	UpstreamBuild    int
+	UserId           string
+	UserName         string
+}
+
+type Change struct {
+	CommitId      string
+	Timestamp     int64
+	Comment       string
+	Author        string
+	AffectedPaths []string
+}
+
+type Stage struct {
+	Id              string
+	Name            string
+	Status          string
+	StartTimeMillis int64
+	DurationMillis  int64
+}
+
+// View is a list view, nested views are its Views. Like Jenkins, it lists the jobs
+// matching IncludeRegex besides its Jobs, a posted config.xml sets IncludeRegex.
+type View struct {
+	Name         string
+	Jobs         []string
+	IncludeRegex string
+	Views        []*View
+	Config       string
+}
+
+// Folder is a folder holding views and subfolders, and the jobs whose full name
+// starts with its path.
+type Folder struct {
+	Name    string
+	Views   []*View
+	Folders []*Folder
+}
+
+// QueueItem waits until it has been polled StartAfter times through its queue item
+// URL, then starts a build of its job with its Causes, or as started by tester.
+type QueueItem struct {
+	Id           int
+	Job          string
+	Params       map[string]string
+	Why          string
+	InQueueSince int64
+	Causes       []Cause
+	StartAfter   int
+	Executable   int
+	Cancelled    bool
+	polls        int
+}
+
+// Request is a request received by the fake server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Form   url.Values
+	Body   string
+	Header http.Header
+}
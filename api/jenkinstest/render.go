@@ -0,0 +1,571 @@
+package jenkinstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (s *Server) findJob(name string) *Job {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return job
+		}
+	}
+	return nil
+}
+
+// jobPath returns the URL path of the job with the given full name.
+func jobPath(name string) string {
+	return "/job/" + strings.ReplaceAll(name, "/", "/job/")
+}
+
+// jobParent returns the path of the folder holding a job, "" for top level jobs.
+func jobParent(name string) string {
+	return name[:max(strings.LastIndex(name, "/"), 0)]
+}
+
+func jobBaseName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+// jobRef renders a reference to a job, as the task of a queue item or a downstream
+// project.
+func jobRef(name string) map[string]any {
+	return map[string]any{"name": jobBaseName(name), "fullName": name}
+}
+
+// folderJobs returns the jobs held directly by the folder with the given path.
+func (s *Server) folderJobs(path string) []*Job {
+	jobs := make([]*Job, 0)
+	for _, job := range s.jobs {
+		if jobParent(job.Name) == path {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+func (s *Server) findFolder(fullName string) *Folder {
+	folders := s.folders
+	var found *Folder
+	for segment := range strings.SplitSeq(fullName, FolderSeparator) {
+		found = nil
+		for _, folder := range folders {
+			if folder.Name == segment {
+				found = folder
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		folders = found.Folders
+	}
+	return found
+}
+
+// splitFolder splits the full name of a view into the folders holding it and its
+// view path.
+func splitFolder(fullName string) (string, string) {
+	if index := strings.LastIndex(fullName, FolderSeparator); index >= 0 {
+		return fullName[:index], fullName[index+len(FolderSeparator):]
+	}
+	return "", fullName
+}
+
+func folderViewName(folderName, viewPath string) string {
+	if folderName == "" {
+		return viewPath
+	}
+	return folderName + FolderSeparator + viewPath
+}
+
+// topViews returns the views of a folder, or of the server for an empty name.
+func (s *Server) topViews(folderName string) *[]*View {
+	if folderName == "" {
+		return &s.views
+	}
+	if folder := s.findFolder(folderName); folder != nil {
+		return &folder.Views
+	}
+	return nil
+}
+
+func (s *Server) findView(fullName string) *View {
+	folderName, viewPath := splitFolder(fullName)
+	top := s.topViews(folderName)
+	if top == nil {
+		return nil
+	}
+	views := *top
+	var found *View
+	for segment := range strings.SplitSeq(strings.Trim(viewPath, "/"), "/") {
+		found = nil
+		for _, view := range views {
+			if view.Name == segment {
+				found = view
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		views = found.Views
+	}
+	return found
+}
+
+func (s *Server) createView(fullName string) (*View, error) {
+	folderName, viewPath := splitFolder(fullName)
+	parentName, name := "", viewPath
+	if index := strings.LastIndex(viewPath, "/"); index >= 0 {
+		parentName, name = viewPath[:index], viewPath[index+1:]
+	}
+	view := &View{Name: name}
+	if parentName == "" {
+		top := s.topViews(folderName)
+		if top == nil {
+			return nil, fmt.Errorf("no folder %s to create %s in", folderName, name)
+		}
+		*top = append(*top, view)
+		return view, nil
+	}
+	parentName = folderViewName(folderName, parentName)
+	parent := s.findView(parentName)
+	if parent == nil {
+		return nil, fmt.Errorf("no view %s to create %s in", parentName, name)
+	}
+	parent.Views = append(parent.Views, view)
+	return view, nil
+}
+
+func (s *Server) deleteView(fullName string) {
+	folderName, viewPath := splitFolder(fullName)
+	target := s.findView(fullName)
+	matches := func(view *View) bool { return view == target }
+	if index := strings.LastIndex(viewPath, "/"); index >= 0 {
+		if parent := s.findView(folderViewName(folderName, viewPath[:index])); parent != nil {
+			parent.Views = slices.DeleteFunc(parent.Views, matches)
+		}
+		return
+	}
+	if top := s.topViews(folderName); top != nil {
+		*top = slices.DeleteFunc(*top, matches)
+	}
+}
+
+func (s *Server) deleteJob(name string) {
+	s.jobs = slices.DeleteFunc(s.jobs, func(job *Job) bool { return job.Name == name })
+	s.eachView(func(view *View) {
+		view.Jobs = slices.DeleteFunc(view.Jobs, func(job string) bool { return job == name })
+	})
+}
+
+func (s *Server) renameJob(job *Job, newName string) {
+	oldName := job.Name
+	job.Name = newName
+	s.eachView(func(view *View) {
+		for index, name := range view.Jobs {
+			if name == oldName {
+				view.Jobs[index] = newName
+			}
+		}
+	})
+	for _, other := range s.jobs {
+		for index, name := range other.Downstream {
+			if name == oldName {
+				other.Downstream[index] = newName
+			}
+		}
+	}
+}
+
+// eachView visits every view of the server and its folders.
+func (s *Server) eachView(visit func(*View)) {
+	s.walkViews(s.views, visit)
+	var walkFolders func(folders []*Folder)
+	walkFolders = func(folders []*Folder) {
+		for _, folder := range folders {
+			s.walkViews(folder.Views, visit)
+			walkFolders(folder.Folders)
+		}
+	}
+	walkFolders(s.folders)
+}
+
+func (s *Server) walkViews(views []*View, visit func(*View)) {
+	for _, view := range views {
+		visit(view)
+		s.walkViews(view.Views, visit)
+	}
+}
+
+func (s *Server) addBuild(job *Job, build *Build) {
+	if build.Number == 0 {
+		build.Number = job.NextBuildNumber
+	}
+	job.NextBuildNumber = max(job.NextBuildNumber, build.Number+1)
+	job.Builds = append(job.Builds, build)
+	sort.Slice(job.Builds, func(i, j int) bool { return job.Builds[i].Number < job.Builds[j].Number })
+}
+
+func findBuild(job *Job, number int) *Build {
+	for _, build := range job.Builds {
+		if build.Number == number {
+			return build
+		}
+	}
+	return nil
+}
+
+// resolveBuild reads a build number or one of the permalinks Jenkins offers.
+func resolveBuild(job *Job, ref string) *Build {
+	if number, err := strconv.Atoi(ref); err == nil {
+		return findBuild(job, number)
+	}
+	var match func(*Build) bool
+	switch ref {
+	case "lastBuild":
+		match = func(*Build) bool { return true }
+	case "lastCompletedBuild":
+		match = func(build *Build) bool { return !build.Building }
+	case "lastSuccessfulBuild", "lastStableBuild":
+		match = func(build *Build) bool { return !build.Building && build.Result == "SUCCESS" }
+	case "lastFailedBuild":
+		match = func(build *Build) bool { return !build.Building && build.Result == "FAILURE" }
+	case "lastUnstableBuild":
+		match = func(build *Build) bool { return !build.Building && build.Result == "UNSTABLE" }
+	case "lastUnsuccessfulBuild":
+		match = func(build *Build) bool { return !build.Building && build.Result != "SUCCESS" }
+	default:
+		return nil
+	}
+	for index := len(job.Builds) - 1; index >= 0; index-- {
+		if match(job.Builds[index]) {
+			return job.Builds[index]
+		}
+	}
+	return nil
+}
+
+func finishBuild(build *Build, result string) {
+	build.Building = false
+	build.Result = result
+	if build.Duration == 0 {
+		build.Duration = build.EstimatedDuration
+	}
+}
+
+var includeRegexPattern = regexp.MustCompile(`<includeRegex>(.*?)</includeRegex>`)
+
+func (s *Server) viewJSON(view *View) map[string]any {
+	jobs := make([]*Job, 0, len(view.Jobs))
+	for _, name := range view.Jobs {
+		if job := s.findJob(name); job != nil {
+			jobs = append(jobs, job)
+		}
+	}
+	if pattern, err := regexp.Compile("^(?:" + view.IncludeRegex + ")$"); err == nil && view.IncludeRegex != "" {
+		for _, job := range s.jobs {
+			if pattern.MatchString(job.Name) && !slices.Contains(jobs, job) {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	body := map[string]any{
+		"_class": "hudson.model.ListView",
+		"name":   view.Name,
+		"url":    s.URL + "/view/" + view.Name + "/",
+		"jobs":   s.jobsJSON(jobs),
+	}
+	if len(view.Views) > 0 {
+		body["_class"] = "hudson.plugins.nested_view.NestedView"
+		nested := make([]any, 0, len(view.Views))
+		for _, child := range view.Views {
+			nested = append(nested, s.viewJSON(child))
+		}
+		body["views"] = nested
+	}
+	return body
+}
+
+// folderJSON renders folder, path being its full name with the folders separated by
+// "/" like in the full names of jobs.
+func (s *Server) folderJSON(folder *Folder, path string) map[string]any {
+	views := make([]any, 0, len(folder.Views))
+	for _, view := range folder.Views {
+		views = append(views, s.viewJSON(view))
+	}
+	jobs := s.jobsJSON(s.folderJobs(path))
+	for _, child := range folder.Folders {
+		jobs = append(jobs, s.folderJSON(child, path+"/"+child.Name))
+	}
+	return map[string]any{
+		"_class": "com.cloudbees.hudson.plugins.folder.Folder",
+		"name":   folder.Name,
+		"views":  views,
+		"jobs":   jobs,
+	}
+}
+
+func (s *Server) viewConfig(view *View) string {
+	if view.Config != "" {
+		return view.Config
+	}
+	var builder strings.Builder
+	builder.WriteString("<hudson.model.ListView>\n  <name>" + view.Name + "</name>\n  <jobNames>\n")
+	for _, job := range view.Jobs {
+		builder.WriteString("    <string>" + job + "</string>\n")
+	}
+	builder.WriteString("  </jobNames>\n")
+	if view.IncludeRegex != "" {
+		builder.WriteString("  <includeRegex>" + view.IncludeRegex + "</includeRegex>\n")
+	}
+	builder.WriteString("</hudson.model.ListView>")
+	return builder.String()
+}
+
+func (s *Server) jobsJSON(jobs []*Job) []any {
+	rendered := make([]any, 0, len(jobs))
+	for _, job := range jobs {
+		rendered = append(rendered, s.jobJSON(job))
+	}
+	return rendered
+}
+
+func (s *Server) jobJSON(job *Job) map[string]any {
+	definitions := make([]any, 0, 2)
+	if len(job.Choices) > 0 {
+		definitions = append(definitions, map[string]any{
+			"_class":  "hudson.model.ChoiceParameterDefinition",
+			"name":    "pro",
+			"type":    "ChoiceParameterDefinition",
+			"choices": job.Choices,
+		})
+	}
+	if len(job.Branches) > 0 {
+		values := make([]any, 0, len(job.Branches))
+		for _, branch := range job.Branches {
+			values = append(values, map[string]any{"name": branch, "value": branch})
+		}
+		definitions = append(definitions, map[string]any{
+			"_class":        gitParameterClass,
+			"name":          "tag",
+			"type":          "PT_BRANCH",
+			"allValueItems": map[string]any{"values": values},
+		})
+	}
+	properties := make([]any, 0, 1)
+	if len(definitions) > 0 {
+		properties = append(properties, map[string]any{
+			"_class":               "hudson.model.ParametersDefinitionProperty",
+			"parameterDefinitions": definitions,
+		})
+	}
+	health := make([]any, 0, len(job.Health))
+	for _, report := range job.Health {
+		health = append(health, map[string]any{"score": report.Score, "description": report.Description})
+	}
+	downstream := make([]any, 0, len(job.Downstream))
+	for _, name := range job.Downstream {
+		downstream = append(downstream, jobRef(name))
+	}
+	builds := make([]any, 0, len(job.Builds))
+	for index := len(job.Builds) - 1; index >= 0; index-- {
+		builds = append(builds, s.buildJSON(job, job.Builds[index]))
+	}
+	body := map[string]any{
+		"_class":             "hudson.model.FreeStyleProject",
+		"name":               jobBaseName(job.Name),
+		"fullName":           job.Name,
+		"url":                s.URL + jobPath(job.Name) + "/",
+		"color":              job.Color,
+		"buildable":          !job.Disabled,
+		"nextBuildNumber":    job.NextBuildNumber,
+		"property":           properties,
+		"healthReport":       health,
+		"downstreamProjects": downstream,
+		"builds":             builds,
+	}
+	for _, permalink := range []string{"lastBuild", "lastSuccessfulBuild", "lastFailedBuild", "lastCompletedBuild"} {
+		if build := resolveBuild(job, permalink); build != nil {
+			body[permalink] = s.buildJSON(job, build)
+		} else {
+			body[permalink] = nil
+		}
+	}
+	return body
+}
+
+func (s *Server) buildJSON(job *Job, build *Build) map[string]any {
+	actions := make([]any, 0, 2)
+	if len(build.Causes) > 0 {
+		causes := make([]any, 0, len(build.Causes))
+		for _, cause := range build.Causes {
+			causes = append(causes, causeJSON(cause))
+		}
+		actions = append(actions, map[string]any{"_class": "hudson.model.CauseAction", "causes": causes})
+	}
+	if len(build.Params) > 0 {
+		names := make([]string, 0, len(build.Params))
+		for name := range build.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		parameters := make([]any, 0, len(names))
+		for _, name := range names {
+			parameters = append(parameters, map[string]any{"name": name, "value": build.Params[name]})
+		}
+		actions = append(actions, map[string]any{"_class": "hudson.model.ParametersAction", "parameters": parameters})
+	}
+	items := make([]any, 0, len(build.ChangeSets))
+	for _, change := range build.ChangeSets {
+		items = append(items, map[string]any{
+			"commitId":      change.CommitId,
+			"timestamp":     change.Timestamp,
+			"comment":       change.Comment,
+			"author":        map[string]any{"fullName": change.Author},
+			"affectedPaths": change.AffectedPaths,
+		})
+	}
+	changeSets := make([]any, 0, 1)
+	if len(items) > 0 {
+		changeSets = append(changeSets, map[string]any{"kind": "git", "items": items})
+	}
+	var result, description any
+	if build.Result != "" {
+		result = build.Result
+	}
+	if build.Description != "" {
+		description = build.Description
+	}
+	return map[string]any{
+		"_class":            "hudson.model.FreeStyleBuild",
+		"number":            build.Number,
+		"url":               s.URL + jobPath(job.Name) + "/" + strconv.Itoa(build.Number) + "/",
+		"fullDisplayName":   strings.ReplaceAll(job.Name, "/", FolderSeparator) + " #" + strconv.Itoa(build.Number),
+		"queueId":           build.QueueId,
+		"result":            result,
+		"building":          build.Building,
+		"duration":          build.Duration,
+		"estimatedDuration": build.EstimatedDuration,
+		"timestamp":         build.Timestamp,
+		"builtOn":           build.BuiltOn,
+		"description":       description,
+		"actions":           actions,
+		"changeSets":        changeSets,
+	}
+}
+
+func causeJSON(cause Cause) map[string]any {
+	body := map[string]any{"_class": "hudson.model.Cause", "shortDescription": cause.ShortDescription}
+	switch {
+	case cause.UpstreamProject != "":
+		body = map[string]any{
+			"_class":           "hudson.model.Cause$UpstreamCause",
+			"shortDescription": fmt.Sprintf("Started by upstream project \"%s\" build number %d", cause.UpstreamProject, cause.UpstreamBuild),
+			"upstreamProject":  cause.UpstreamProject,
+			"upstreamBuild":    cause.UpstreamBuild,
+		}
+	case cause.UserId != "":
+		body = map[string]any{
+			"_class":           "hudson.model.Cause$UserIdCause",
+			"shortDescription": "Started by user " + cause.UserName,
+			"userId":           cause.UserId,
+			"userName":         cause.UserName,
+		}
+	}
+	if cause.Class != "" {
+		body["_class"] = cause.Class
+	}
+	return body
+}
+
+func wfDescribeJSON(build *Build) map[string]any {
+	status := "SUCCESS"
+	switch {
+	case build.Building:
+		status = "IN_PROGRESS"
+	case build.Result == "FAILURE":
+		status = "FAILED"
+	case build.Result == "ABORTED":
+		status = "ABORTED"
+	case build.Result == "UNSTABLE":
+		status = "UNSTABLE"
+	}
+	stages := make([]any, 0, len(build.Stages))
+	for _, stage := range build.Stages {
+		stages = append(stages, map[string]any{
+			"id":              stage.Id,
+			"name":            stage.Name,
+			"status":          stage.Status,
+			"startTimeMillis": stage.StartTimeMillis,
+			"durationMillis":  stage.DurationMillis,
+		})
+	}
+	return map[string]any{
+		"id":              strconv.Itoa(build.Number),
+		"name":            "#" + strconv.Itoa(build.Number),
+		"status":          status,
+		"startTimeMillis": build.Timestamp,
+		"endTimeMillis":   build.Timestamp + build.Duration,
+		"durationMillis":  build.Duration,
+		"stages":          stages,
+	}
+}
+
+// formatQueueParams renders parameters the way the queue API lists them.
+func formatQueueParams(params map[string]string) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var builder strings.Builder
+	for _, name := range names {
+		builder.WriteString("\n" + name + "=" + params[name])
+	}
+	return builder.String()
+}
+
+func viewPath(fullName string) string {
+	folderName, path := splitFolder(fullName)
+	prefix := ""
+	if folderName != "" {
+		prefix = jobPath(strings.ReplaceAll(folderName, FolderSeparator, "/"))
+	}
+	return prefix + "/view/" + strings.ReplaceAll(path, "/", "/view/")
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.Write(data)
+}
+
+func writeXML(w http.ResponseWriter, document string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.Write([]byte(document))
+}
+
+func redirect(w http.ResponseWriter, location string) {
+	w.Header().Set("Location", location)
+	w.WriteHeader(http.StatusFound)
+}
+
+func first(values []string, fallback string) string {
+	if len(values) > 0 {
+		return values[0]
+	}
+	return fallback
+}
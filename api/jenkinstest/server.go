@@ -0,0 +1,713 @@
+// Package jenkinstest provides an in-memory Jenkins for tests and offline demos. It
+// serves the JSON API, config.xml documents, the queue, progressive logs and the
+// pipeline REST API the api package relies on, backed by jobs, builds and views a
+// test sets up and scripts. Like Jenkins, it honours the tree query parameter.
+package jenkinstest
+
+import (
+	"encoding/base64"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const gitParameterClass = "net.uaznia.lukanus.hudson.plugins.gitparameter.GitParameterDefinition"
+
+const DefaultCrumbField = "Jenkins-Crumb"
+const DefaultCrumb = "test-crumb"
+const SessionCookie = "JSESSIONID"
+
+// FolderSeparator separates folders, and the folders from the views they hold, in
+// the full names of AddFolder and AddView, e.g. "platform » tools » backend/api".
+const FolderSeparator = " » "
+
+// Server is a fake Jenkins. The zero configuration accepts any credentials and
+// issues a crumb that POST requests must carry.
+type Server struct {
+	*httptest.Server
+
+	// Username and Token enable basic authentication when set.
+	Username string
+	Token    string
+	// Crumb is required on POST requests, an empty crumb disables the crumb issuer.
+	CrumbField string
+	Crumb      string
+	// SessionCrumbs binds each crumb to a session cookie the crumb issuer sets, as
+	// Jenkins does by default.
+	SessionCrumbs bool
+	// QueueDelay is the StartAfter of new queue items.
+	QueueDelay int
+
+	mu          sync.Mutex
+	jobs        []*Job
+	views       []*View
+	folders     []*Folder
+	items       []*QueueItem
+	nextQueueId int
+	sessions    int
+	minSession  int
+	requests    []Request
+	overrides   map[string]*override
+}
+
+type override struct {
+	handler http.HandlerFunc
+	times   int
+}
+
+// NewServer starts a fake Jenkins with an "all" view, close it with Close.
+func NewServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewServerAt is NewServer listening on addr, for demos that need a stable URL.
+func NewServerAt(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	s := newServer()
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	s.Server.Listener.Close()
+	s.Server.Listener = listener
+	s.Server.Start()
+	return s, nil
+}
+
+func newServer() *Server {
+	return &Server{
+		CrumbField:  DefaultCrumbField,
+		Crumb:       DefaultCrumb,
+		views:       []*View{{Name: "all"}},
+		nextQueueId: 1,
+		overrides:   make(map[string]*override),
+	}
+}
+
+// AddJob registers job and lists it in the "all" view. A job named like
+// "platform/api" belongs to the folder platform, which must exist, and is only
+// listed by the views it is added to.
+func (s *Server) AddJob(job *Job) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	folderName := strings.ReplaceAll(jobParent(job.Name), "/", FolderSeparator)
+	if folderName != "" && s.findFolder(folderName) == nil {
+		panic("jenkinstest: unknown folder " + folderName)
+	}
+	if job.Color == "" {
+		job.Color = "blue"
+	}
+	if job.NextBuildNumber == 0 {
+		job.NextBuildNumber = 1
+		for _, build := range job.Builds {
+			job.NextBuildNumber = max(job.NextBuildNumber, build.Number+1)
+		}
+	}
+	if job.Config == "" {
+		job.Config = "<project>\n  <description>" + job.Name + "</description>\n</project>"
+	}
+	s.jobs = append(s.jobs, job)
+	if all := s.findView("all"); all != nil && folderName == "" {
+		all.Jobs = append(all.Jobs, job.Name)
+	}
+	return job
+}
+
+// AddBuild appends build to the builds of jobName.
+func (s *Server) AddBuild(jobName string, build *Build) *Build {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job := s.findJob(jobName)
+	if job == nil {
+		panic("jenkinstest: unknown job " + jobName)
+	}
+	s.addBuild(job, build)
+	return build
+}
+
+// AddFolder creates the folder with the given full name, subfolders being separated
+// by FolderSeparator. The parent folder must exist.
+func (s *Server) AddFolder(fullName string) *Folder {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	folder := &Folder{Name: fullName}
+	if index := strings.LastIndex(fullName, FolderSeparator); index >= 0 {
+		parent := s.findFolder(fullName[:index])
+		if parent == nil {
+			panic("jenkinstest: unknown folder " + fullName[:index])
+		}
+		folder.Name = fullName[index+len(FolderSeparator):]
+		parent.Folders = append(parent.Folders, folder)
+		return folder
+	}
+	s.folders = append(s.folders, folder)
+	return folder
+}
+
+// AddView creates the view with the given full name, nested views being separated
+// by "/" and preceded by the folders holding them, such as "platform » backend". The
+// parent view or folder must exist.
+func (s *Server) AddView(fullName string, jobs ...string) *View {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	view, err := s.createView(fullName)
+	if err != nil {
+		panic("jenkinstest: " + err.Error())
+	}
+	view.Jobs = append(view.Jobs, jobs...)
+	return view
+}
+
+// Update runs change with exclusive access to the state of the server, for
+// scenarios modifying jobs or builds while a client polls.
+func (s *Server) Update(change func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change()
+}
+
+func (s *Server) Job(name string) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.findJob(name)
+}
+
+func (s *Server) View(fullName string) *View {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.findView(fullName)
+}
+
+func (s *Server) Build(jobName string, number int) *Build {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job := s.findJob(jobName); job != nil {
+		return findBuild(job, number)
+	}
+	return nil
+}
+
+// Enqueue adds a queue item for jobName as if it had been triggered.
+func (s *Server) Enqueue(jobName string, params map[string]string) *QueueItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enqueue(jobName, params)
+}
+
+// Queue returns the items waiting in the queue.
+func (s *Server) Queue() []*QueueItem {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.waiting()
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// RequestsTo returns the requests of method to path.
+func (s *Server) RequestsTo(method, path string) []Request {
+	matching := make([]Request, 0)
+	for _, request := range s.Requests() {
+		if request.Method == method && request.Path == path {
+			matching = append(matching, request)
+		}
+	}
+	return matching
+}
+
+// Handle replaces the handling of method and path, times limits how often the
+// replacement applies, 0 meaning always.
+func (s *Server) Handle(method, path string, times int, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[method+" "+path] = &override{handler: handler, times: times}
+}
+
+// Fail answers the next times requests of method to path with status.
+func (s *Server) Fail(method, path string, status, times int) {
+	s.Handle(method, path, times, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(status), status)
+	})
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	request := Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   string(body),
+		Header: r.Header.Clone(),
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			request.Form = form
+		}
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, request)
+	key := r.Method + " " + strings.TrimSuffix(r.URL.Path, "/")
+	if replacement, ok := s.overrides[key]; ok {
+		if replacement.times > 0 {
+			replacement.times--
+			if replacement.times == 0 {
+				delete(s.overrides, key)
+			}
+		}
+		s.mu.Unlock()
+		replacement.handler(w, r)
+		return
+	}
+	defer s.mu.Unlock()
+
+	if s.Username != "" && !s.authorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if r.Method == http.MethodPost && s.Crumb != "" && !s.validCrumb(r) {
+		http.Error(w, "No valid crumb was included in the request", http.StatusForbidden)
+		return
+	}
+	if tree := r.URL.Query().Get("tree"); tree != "" {
+		s.routeTree(w, request, tree)
+		return
+	}
+	s.route(w, request)
+}
+
+// ExpireSessions invalidates the crumbs issued so far, as a Jenkins restart or an
+// expired session does.
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.minSession = s.sessions + 1
+}
+
+func (s *Server) validCrumb(r *http.Request) bool {
+	if !s.SessionCrumbs {
+		return r.Header.Get(s.CrumbField) == s.Crumb
+	}
+	cookie, err := r.Cookie(SessionCookie)
+	if err != nil {
+		return false
+	}
+	id, err := strconv.Atoi(cookie.Value)
+	return err == nil && id >= s.minSession && r.Header.Get(s.CrumbField) == s.Crumb+"-"+cookie.Value
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte(s.Username+":"+s.Token))
+	return r.Header.Get("Authorization") == expected
+}
+
+func (s *Server) route(w http.ResponseWriter, r Request) {
+	parts := strings.Split(strings.Trim(r.Path, "/"), "/")
+	folderNames := make([]string, 0)
+	for len(parts) >= 2 && parts[0] == "job" && s.findFolder(strings.Join(append(slices.Clone(folderNames), parts[1]), FolderSeparator)) != nil {
+		folderNames = append(folderNames, parts[1])
+		parts = parts[2:]
+	}
+	folderName := strings.Join(folderNames, FolderSeparator)
+	viewNames := make([]string, 0)
+	for len(parts) >= 2 && parts[0] == "view" {
+		viewNames = append(viewNames, parts[1])
+		parts = parts[2:]
+	}
+	rest := strings.Join(parts, "/")
+	if len(viewNames) > 0 {
+		s.routeView(w, r, folderName, strings.Join(viewNames, "/"), rest)
+		return
+	}
+	if folderName != "" {
+		switch {
+		case r.Method == http.MethodGet && rest == "api/json":
+			writeJSON(w, s.folderJSON(s.findFolder(folderName), strings.Join(folderNames, "/")))
+		case r.Method == http.MethodPost && rest == "createView":
+			s.routeView(w, r, folderName, "", rest)
+		case len(parts) >= 2 && parts[0] == "job":
+			job := s.findJob(strings.Join(folderNames, "/") + "/" + parts[1])
+			if job == nil {
+				http.NotFound(w, nil)
+				return
+			}
+			s.routeJob(w, r, job, parts[2:])
+		default:
+			http.NotFound(w, nil)
+		}
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && rest == "api/json":
+		views := make([]any, 0, len(s.views))
+		for _, view := range s.views {
+			views = append(views, s.viewJSON(view))
+		}
+		jobs := s.jobsJSON(s.folderJobs(""))
+		for _, folder := range s.folders {
+			jobs = append(jobs, s.folderJSON(folder, folder.Name))
+		}
+		writeJSON(w, map[string]any{"views": views, "jobs": jobs})
+	case r.Method == http.MethodGet && rest == "crumbIssuer/api/json":
+		if s.Crumb == "" {
+			http.NotFound(w, nil)
+			return
+		}
+		crumb := s.Crumb
+		if s.SessionCrumbs {
+			s.sessions++
+			id := strconv.Itoa(s.sessions)
+			http.SetCookie(w, &http.Cookie{Name: SessionCookie, Value: id, Path: "/"})
+			crumb += "-" + id
+		}
+		writeJSON(w, map[string]any{"crumb": crumb, "crumbRequestField": s.CrumbField})
+	case r.Method == http.MethodPost && rest == "createView":
+		s.routeView(w, r, "", "", rest)
+	case r.Method == http.MethodPost && rest == "createItem":
+		s.createItem(w, r)
+	case r.Method == http.MethodGet && rest == "queue/api/json":
+		items := make([]any, 0)
+		for _, item := range s.waiting() {
+			causes := make([]any, 0, len(item.Causes))
+			for _, cause := range item.Causes {
+				causes = append(causes, causeJSON(cause))
+			}
+			items = append(items, map[string]any{
+				"_class":       "hudson.model.Queue$WaitingItem",
+				"actions":      []any{map[string]any{"_class": "hudson.model.CauseAction", "causes": causes}},
+				"id":           item.Id,
+				"task":         jobRef(item.Job),
+				"params":       formatQueueParams(item.Params),
+				"why":          item.Why,
+				"blocked":      false,
+				"stuck":        false,
+				"inQueueSince": item.InQueueSince,
+			})
+		}
+		writeJSON(w, map[string]any{"items": items})
+	case strings.HasPrefix(rest, "queue/item/") && strings.HasSuffix(rest, "/api/json"):
+		s.queueItem(w, strings.TrimSuffix(strings.TrimPrefix(rest, "queue/item/"), "/api/json"))
+	case r.Method == http.MethodPost && rest == "queue/cancelItem":
+		id, _ := strconv.Atoi(first(r.Query["id"], first(r.Form["id"], "")))
+		item := s.findQueueItem(id)
+		if item == nil || item.Executable != 0 {
+			http.NotFound(w, nil)
+			return
+		}
+		item.Cancelled = true
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet && rest == "computer/api/json":
+		executors := make([]any, 0)
+		for _, job := range s.jobs {
+			for _, build := range job.Builds {
+				if build.Building {
+					executors = append(executors, map[string]any{"currentExecutable": map[string]any{
+						"number": build.Number,
+						"url":    s.URL + jobPath(job.Name) + "/" + strconv.Itoa(build.Number) + "/",
+					}})
+				}
+			}
+		}
+		writeJSON(w, map[string]any{"computer": []any{map[string]any{
+			"displayName":        "Built-In Node",
+			"executors":          []any{},
+			"oneOffExecutors":    executors,
+			"numExecutors":       2,
+			"temporarilyOffline": false,
+		}}})
+	case len(parts) >= 2 && parts[0] == "job":
+		job := s.findJob(parts[1])
+		if job == nil {
+			http.NotFound(w, nil)
+			return
+		}
+		s.routeJob(w, r, job, parts[2:])
+	default:
+		http.NotFound(w, nil)
+	}
+}
+
+// routeView answers the requests to the view with the path views in folderName, or
+// to the folder or Jenkins itself for empty views.
+func (s *Server) routeView(w http.ResponseWriter, r Request, folderName, views, rest string) {
+	viewName := folderViewName(folderName, views)
+	view := s.findView(viewName)
+	if views != "" && view == nil {
+		http.NotFound(w, nil)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && rest == "api/json":
+		writeJSON(w, s.viewJSON(view))
+	case r.Method == http.MethodGet && rest == "config.xml":
+		writeXML(w, s.viewConfig(view))
+	case r.Method == http.MethodPost && rest == "config.xml":
+		view.Config = r.Body
+		view.IncludeRegex = ""
+		if match := includeRegexPattern.FindStringSubmatch(r.Body); match != nil {
+			view.IncludeRegex = html.UnescapeString(match[1])
+		}
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && rest == "createView":
+		name := first(r.Form["name"], "")
+		fullName := folderViewName(folderName, name)
+		if views != "" {
+			fullName = viewName + "/" + name
+		}
+		if name == "" || s.findView(fullName) != nil {
+			http.Error(w, "A view already exists with the name "+name, http.StatusBadRequest)
+			return
+		}
+		if _, err := s.createView(fullName); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		redirect(w, s.URL+viewPath(fullName)+"/")
+	case r.Method == http.MethodPost && rest == "addJobToView":
+		name := first(r.Query["name"], "")
+		if s.findJob(name) == nil {
+			http.Error(w, "Query parameter 'name' does not correspond to a known item", http.StatusBadRequest)
+			return
+		}
+		if !slices.Contains(view.Jobs, name) {
+			view.Jobs = append(view.Jobs, name)
+		}
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && rest == "removeJobFromView":
+		view.Jobs = slices.DeleteFunc(view.Jobs, func(job string) bool { return job == first(r.Query["name"], "") })
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && rest == "doDelete":
+		s.deleteView(viewName)
+		redirect(w, s.URL+"/")
+	default:
+		http.NotFound(w, nil)
+	}
+}
+
+func (s *Server) routeJob(w http.ResponseWriter, r Request, job *Job, parts []string) {
+	rest := strings.Join(parts, "/")
+	switch {
+	case r.Method == http.MethodGet && rest == "api/json":
+		writeJSON(w, s.jobJSON(job))
+	case r.Method == http.MethodGet && rest == "config.xml":
+		writeXML(w, job.Config)
+	case r.Method == http.MethodPost && rest == "config.xml":
+		job.Config = r.Body
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPost && rest == "enable":
+		job.Disabled = false
+		job.Color = "notbuilt"
+		redirect(w, s.URL+jobPath(job.Name)+"/")
+	case r.Method == http.MethodPost && rest == "disable":
+		job.Disabled = true
+		job.Color = "disabled"
+		redirect(w, s.URL+jobPath(job.Name)+"/")
+	case r.Method == http.MethodPost && rest == "doDelete":
+		s.deleteJob(job.Name)
+		redirect(w, s.URL+"/")
+	case r.Method == http.MethodPost && rest == "confirmRename":
+		newName := first(r.Form["newName"], "")
+		if parent := jobParent(job.Name); parent != "" && newName != "" {
+			newName = parent + "/" + newName
+		}
+		if newName == "" || s.findJob(newName) != nil {
+			http.Error(w, "invalid name "+newName, http.StatusBadRequest)
+			return
+		}
+		s.renameJob(job, newName)
+		redirect(w, s.URL+jobPath(newName)+"/")
+	case r.Method == http.MethodPost && (rest == "build" || rest == "buildWithParameters"):
+		params := make(map[string]string)
+		for key, values := range r.Form {
+			params[key] = first(values, "")
+		}
+		for key, values := range r.Query {
+			params[key] = first(values, "")
+		}
+		item := s.enqueue(job.Name, params)
+		w.Header().Set("Location", s.URL+"/queue/item/"+strconv.Itoa(item.Id)+"/")
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPost && rest == "descriptorByName/"+gitParameterClass+"/fillValueItems":
+		values := make([]any, 0, len(job.Branches))
+		for _, branch := range job.Branches {
+			values = append(values, map[string]any{"name": branch, "value": branch})
+		}
+		writeJSON(w, map[string]any{"_class": "hudson.util.ListBoxModel", "values": values})
+	case r.Method == http.MethodGet && rest == "wfapi/runs":
+		runs := make([]any, 0)
+		for index := len(job.Builds) - 1; index >= 0; index-- {
+			runs = append(runs, wfDescribeJSON(job.Builds[index]))
+		}
+		writeJSON(w, runs)
+	case len(parts) >= 1:
+		build := resolveBuild(job, parts[0])
+		if build == nil {
+			http.NotFound(w, nil)
+			return
+		}
+		s.routeBuild(w, r, job, build, strings.Join(parts[1:], "/"))
+	default:
+		http.NotFound(w, nil)
+	}
+}
+
+func (s *Server) routeBuild(w http.ResponseWriter, r Request, job *Job, build *Build, rest string) {
+	switch {
+	case r.Method == http.MethodGet && rest == "api/json":
+		writeJSON(w, s.buildJSON(job, build))
+	case r.Method == http.MethodGet && rest == "logText/progressiveText":
+		start, _ := strconv.Atoi(first(r.Query["start"], "0"))
+		if start >= len(build.Log) && len(build.LogChunks) > 0 {
+			build.Log += build.LogChunks[0]
+			build.LogChunks = build.LogChunks[1:]
+			if len(build.LogChunks) == 0 && build.FinalResult != "" {
+				finishBuild(build, build.FinalResult)
+			}
+		}
+		start = min(max(start, 0), len(build.Log))
+		w.Header().Set("Content-Type", "text/plain;charset=UTF-8")
+		w.Header().Set("X-Text-Size", strconv.Itoa(len(build.Log)))
+		if build.Building {
+			w.Header().Set("X-More-Data", "true")
+		}
+		io.WriteString(w, build.Log[start:])
+	case r.Method == http.MethodGet && rest == "wfapi/describe":
+		writeJSON(w, wfDescribeJSON(build))
+	case r.Method == http.MethodPost && rest == "stop":
+		if build.Building {
+			finishBuild(build, "ABORTED")
+		}
+		redirect(w, s.URL+jobPath(job.Name)+"/"+strconv.Itoa(build.Number)+"/")
+	default:
+		http.NotFound(w, nil)
+	}
+}
+
+func (s *Server) createItem(w http.ResponseWriter, r Request) {
+	name := first(r.Query["name"], "")
+	if name == "" || s.findJob(name) != nil {
+		http.Error(w, "A job already exists with the name "+name, http.StatusBadRequest)
+		return
+	}
+	job := &Job{Name: name, Color: "notbuilt", NextBuildNumber: 1, Config: r.Body}
+	if first(r.Query["mode"], "") == "copy" {
+		from := s.findJob(first(r.Query["from"], ""))
+		if from == nil {
+			http.Error(w, "No such job", http.StatusBadRequest)
+			return
+		}
+		job.Config = from.Config
+		job.Choices = slices.Clone(from.Choices)
+		job.Branches = slices.Clone(from.Branches)
+	} else if !strings.Contains(r.Body, "<") {
+		http.Error(w, "No config.xml posted", http.StatusBadRequest)
+		return
+	}
+	s.jobs = append(s.jobs, job)
+	if all := s.findView("all"); all != nil {
+		all.Jobs = append(all.Jobs, name)
+	}
+	redirect(w, s.URL+jobPath(name)+"/")
+}
+
+func (s *Server) queueItem(w http.ResponseWriter, idText string) {
+	id, _ := strconv.Atoi(idText)
+	item := s.findQueueItem(id)
+	if item == nil {
+		http.NotFound(w, nil)
+		return
+	}
+	item.polls++
+	if item.Executable == 0 && !item.Cancelled && item.polls > item.StartAfter {
+		s.startQueued(item)
+	}
+	body := map[string]any{
+		"id":           item.Id,
+		"task":         jobRef(item.Job),
+		"why":          item.Why,
+		"inQueueSince": item.InQueueSince,
+		"cancelled":    item.Cancelled,
+	}
+	if item.Executable != 0 {
+		body["why"] = nil
+		body["executable"] = map[string]any{
+			"number": item.Executable,
+			"url":    s.URL + jobPath(item.Job) + "/" + strconv.Itoa(item.Executable) + "/",
+		}
+	}
+	writeJSON(w, body)
+}
+
+func (s *Server) enqueue(jobName string, params map[string]string) *QueueItem {
+	item := &QueueItem{
+		Id:           s.nextQueueId,
+		Job:          jobName,
+		Params:       params,
+		Why:          "Waiting for next available executor",
+		InQueueSince: time.Now().UnixMilli(),
+		StartAfter:   s.QueueDelay,
+	}
+	s.nextQueueId++
+	s.items = append(s.items, item)
+	return item
+}
+
+// startQueued turns a queue item into a running build. The item URL keeps
+// resolving to the build, but the item no longer shows in the queue.
+func (s *Server) startQueued(item *QueueItem) {
+	job := s.findJob(item.Job)
+	if job == nil {
+		return
+	}
+	build := &Build{
+		Building:          true,
+		Timestamp:         time.Now().UnixMilli(),
+		EstimatedDuration: 60000,
+		QueueId:           item.Id,
+		Params:            item.Params,
+		Causes:            item.Causes,
+	}
+	if len(build.Causes) == 0 {
+		build.Causes = []Cause{{ShortDescription: "Started by user tester", UserId: "tester", UserName: "tester"}}
+	}
+	s.addBuild(job, build)
+	if job.OnStart != nil {
+		job.OnStart(build)
+	}
+	item.Executable = build.Number
+	item.Why = ""
+}
+
+func (s *Server) waiting() []*QueueItem {
+	waiting := make([]*QueueItem, 0)
+	for _, item := range s.items {
+		if item.Executable == 0 && !item.Cancelled {
+			waiting = append(waiting, item)
+		}
+	}
+	return waiting
+}
+
+func (s *Server) findQueueItem(id int) *QueueItem {
+	for _, item := range s.items {
+		if item.Id == id {
+			return item
+		}
+	}
+	return nil
+}
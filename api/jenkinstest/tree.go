@@ -0,0 +1,178 @@
+package jenkinstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+)
+
+// treeField is a field of a tree query parameter. A field without nested fields
+// renders scalars and arrays of scalars, objects are reduced to their _class like
+// Jenkins does.
+type treeField struct {
+	name    string
+	nested  []treeField
+	limited bool
+	from    int
+	to      int // -1 for no upper bound
+}
+
+// routeTree answers a request carrying a tree query parameter, keeping only the
+// selected fields of JSON responses.
+func (s *Server) routeTree(w http.ResponseWriter, r Request, tree string) {
+	fields, err := parseTree(tree)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	recorder := httptest.NewRecorder()
+	s.route(recorder, r)
+	body := recorder.Body.Bytes()
+	if recorder.Code == http.StatusOK && strings.HasPrefix(recorder.Header().Get("Content-Type"), "application/json") {
+		var value any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err == nil {
+			if filtered, err := json.Marshal(filterTree(value, fields)); err == nil {
+				body = filtered
+			}
+		}
+	}
+	maps.Copy(w.Header(), recorder.Header())
+	w.WriteHeader(recorder.Code)
+	w.Write(body)
+}
+
+func filterTree(value any, fields []treeField) any {
+	switch value := value.(type) {
+	case map[string]any:
+		filtered := make(map[string]any)
+		if class, ok := value["_class"]; ok {
+			filtered["_class"] = class
+		}
+		for _, field := range fields {
+			if child, ok := value[field.name]; ok {
+				filtered[field.name] = filterField(child, field)
+			}
+		}
+		return filtered
+	case []any:
+		filtered := make([]any, 0, len(value))
+		for _, element := range value {
+			filtered = append(filtered, filterTree(element, fields))
+		}
+		return filtered
+	}
+	return value
+}
+
+func filterField(value any, field treeField) any {
+	if elements, ok := value.([]any); ok && field.limited {
+		from, to := min(field.from, len(elements)), len(elements)
+		if field.to >= 0 {
+			to = min(field.to, len(elements))
+		}
+		value = elements[from:max(from, to)]
+	}
+	return filterTree(value, field.nested)
+}
+
+// parseTree parses the fields of a tree query parameter such as
+// "name,builds[number,result]{0,10}".
+func parseTree(spec string) ([]treeField, error) {
+	parser := &treeParser{spec: spec}
+	fields, err := parser.fields()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(spec) {
+		return nil, fmt.Errorf("unexpected %q at %d in tree %q", spec[parser.pos], parser.pos, spec)
+	}
+	return fields, nil
+}
+
+type treeParser struct {
+	spec string
+	pos  int
+}
+
+func (p *treeParser) fields() ([]treeField, error) {
+	fields := make([]treeField, 0)
+	for {
+		field, err := p.field()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		if !p.accept(',') {
+			return fields, nil
+		}
+	}
+}
+
+func (p *treeParser) field() (treeField, error) {
+	start := p.pos
+	for p.pos < len(p.spec) && !strings.ContainsRune(",[]{}", rune(p.spec[p.pos])) {
+		p.pos++
+	}
+	field := treeField{name: p.spec[start:p.pos], to: -1}
+	if field.name == "" {
+		return field, fmt.Errorf("missing field name at %d in tree %q", p.pos, p.spec)
+	}
+	if p.accept('[') {
+		nested, err := p.fields()
+		if err != nil {
+			return field, err
+		}
+		if !p.accept(']') {
+			return field, fmt.Errorf("missing ] at %d in tree %q", p.pos, p.spec)
+		}
+		field.nested = nested
+	}
+	if p.accept('{') {
+		end := strings.IndexByte(p.spec[p.pos:], '}')
+		if end < 0 {
+			return field, fmt.Errorf("missing } at %d in tree %q", p.pos, p.spec)
+		}
+		if err := field.parseRange(p.spec[p.pos : p.pos+end]); err != nil {
+			return field, fmt.Errorf("invalid range in tree %q: %w", p.spec, err)
+		}
+		p.pos += end + 1
+	}
+	return field, nil
+}
+
+func (p *treeParser) accept(char byte) bool {
+	if p.pos < len(p.spec) && p.spec[p.pos] == char {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseRange reads the range forms Jenkins supports: {M,N}, {M,}, {,N} and {N}.
+func (f *treeField) parseRange(text string) error {
+	f.limited = true
+	from, to, found := strings.Cut(text, ",")
+	bound := func(text string, fallback int) (int, error) {
+		if text == "" {
+			return fallback, nil
+		}
+		return strconv.Atoi(text)
+	}
+	var err error
+	if f.from, err = bound(from, 0); err != nil {
+		return err
+	}
+	if !found {
+		f.to = f.from + 1
+		return nil
+	}
+	f.to, err = bound(to, -1)
+	return err
+}
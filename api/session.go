@@ -0,0 +1,71 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"sync"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+// session is what Jenkins ties to a client across requests: the session cookies and
+// the crumb issued for them. Jenkins binds crumbs to the web session by default, so
+// the crumb is only valid together with the cookies received when it was issued.
+type session struct {
+	jar http.CookieJar
+
+	mu         sync.Mutex
+	fetched    bool
+	crumbField string
+	crumb      string
+}
+
+var (
+	sessionsMu sync.Mutex
+	sessions   = make(map[string]*session)
+)
+
+// sessionFor returns the session of the account, shared by every request of the
+// process.
+func sessionFor(cfg config.JenkinsConfig) *session {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+	key := cfg.BaseApi + "\x00" + cfg.Username
+	if existing, ok := sessions[key]; ok {
+		return existing
+	}
+	// cookiejar.New only fails for an invalid public suffix list.
+	jar, _ := cookiejar.New(nil)
+	created := &session{jar: jar}
+	sessions[key] = created
+	return created
+}
+
+// crumbFor returns the crumb header to send with a POST, fetching it once per
+// session or again when refresh is set after Jenkins refused the previous one. An
+// empty field means the server does not use crumbs.
+func (s *session) crumbFor(cfg config.JenkinsConfig, refresh bool) (string, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fetched && !refresh {
+		return s.crumbField, s.crumb, nil
+	}
+	fetch := cfg
+	fetch.RefreshCache = fetch.RefreshCache || refresh
+	crumbField, crumb, err := GetCrumb(fetch)
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) {
+		// CSRF protection is disabled, there is no crumb issuer.
+		crumbField, crumb, err = "", "", nil
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get crumb: %w", err)
+	}
+	if crumbField == "" || crumb == "" {
+		crumbField, crumb = "", ""
+	}
+	s.fetched, s.crumbField, s.crumb = true, crumbField, crumb
+	return crumbField, crumb, nil
+}
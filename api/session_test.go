@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestSessionBoundCrumbs(t *testing.T) {
+	server, account := newTestServer(t)
+	server.SessionCrumbs = true
+	if err := DisableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	if err := EnableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	if requests := len(server.RequestsTo(http.MethodGet, "/crumbIssuer/api/json")); requests != 1 {
+		t.Errorf("crumb fetched %d times for one session, want 1", requests)
+	}
+
+	server.ExpireSessions()
+	if err := DisableJob(account, "infra"); err != nil {
+		t.Fatalf("POST after the session expired = %v", err)
+	}
+	if requests := len(server.RequestsTo(http.MethodGet, "/crumbIssuer/api/json")); requests != 2 {
+		t.Errorf("crumb fetched %d times after the session expired, want 2", requests)
+	}
+	if requests := len(server.RequestsTo(http.MethodPost, "/job/infra/disable")); requests != 3 {
+		t.Errorf("%d disable requests, want 3 with the retry", requests)
+	}
+}
+
+func TestSessionCrumbsAreNotCached(t *testing.T) {
+	server, account := newTestServer(t)
+	server.SessionCrumbs = true
+	SetTransport(NewCache(t.TempDir(), time.Minute, nil))
+	t.Cleanup(func() { SetTransport(nil) })
+	for range 2 {
+		if _, _, err := GetCrumb(account); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if requests := len(server.RequestsTo(http.MethodGet, "/crumbIssuer/api/json")); requests != 2 {
+		t.Errorf("session crumb fetched %d times, want 2", requests)
+	}
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Bodies longer than this are cut in debug traces.
+const traceBodyLimit = 4096
+
+// Tracer is a transport logging every request to its outputs: method, URL, status,
+// latency and response size, plus redacted headers and truncated bodies for debug
+// outputs.
+type Tracer struct {
+	next    http.RoundTripper
+	mu      sync.Mutex
+	outputs []traceOutput
+}
+
+type traceOutput struct {
+	out   io.Writer
+	debug bool
+}
+
+func NewTracer(next http.RoundTripper) *Tracer {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Tracer{next: next}
+}
+
+// AddOutput logs to out, with headers and bodies when debug is set.
+func (t *Tracer) AddOutput(out io.Writer, debug bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.outputs = append(t.outputs, traceOutput{out: out, debug: debug})
+}
+
+func (t *Tracer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestBody = data
+		req.Body = io.NopCloser(bytes.NewReader(data))
+	}
+
+	start := time.Now()
+	response, err := t.next.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.write(func(debug bool) string {
+			text := fmt.Sprintf("%s %s %s → error after %s: %v\n", start.Format("15:04:05.000"), req.Method, req.URL, latency, err)
+			if debug {
+				text += formatTraceMessage(">", req.Header, requestBody)
+			}
+			return text
+		})
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	t.write(func(debug bool) string {
+		text := fmt.Sprintf("%s %s %s → %d (%s, %d bytes)\n", start.Format("15:04:05.000"), req.Method, req.URL, response.StatusCode, latency, len(responseBody))
+		if debug {
+			text += formatTraceMessage(">", req.Header, requestBody)
+			text += formatTraceMessage("<", response.Header, redactBody(req, responseBody))
+		}
+		return text
+	})
+	return response, nil
+}
+
+func (t *Tracer) write(format func(debug bool) string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, output := range t.outputs {
+		io.WriteString(output.out, format(output.debug))
+	}
+}
+
+// formatTraceMessage prints headers and body of one side of an exchange, each line
+// prefixed with direction.
+func formatTraceMessage(direction string, header http.Header, body []byte) string {
+	var builder strings.Builder
+	redacted := redactHeader(header)
+	names := make([]string, 0, len(redacted))
+	for name := range redacted {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		for _, value := range redacted[name] {
+			fmt.Fprintf(&builder, "%s %s: %s\n", direction, name, value)
+		}
+	}
+	if len(body) == 0 {
+		return builder.String()
+	}
+	if !utf8.Valid(body) {
+		fmt.Fprintf(&builder, "%s [%d bytes of binary data]\n", direction, len(body))
+		return builder.String()
+	}
+	text := string(body)
+	if len(text) > traceBodyLimit {
+		cut := traceBodyLimit
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		text = fmt.Sprintf("%s… [%d more bytes]", text[:cut], len(body)-cut)
+	}
+	for line := range strings.SplitSeq(strings.TrimRight(text, "\n"), "\n") {
+		fmt.Fprintf(&builder, "%s %s\n", direction, line)
+	}
+	return builder.String()
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+)
+
+func TestTracer(t *testing.T) {
+	server, account := newTestServer(t)
+	job := server.Job("svc-api")
+	server.Update(func() { job.Config = "<project>" + strings.Repeat("x", traceBodyLimit) + "</project>" })
+
+	var verbose, debug bytes.Buffer
+	tracer := NewTracer(nil)
+	tracer.AddOutput(&verbose, false)
+	tracer.AddOutput(&debug, true)
+	SetTransport(tracer)
+	t.Cleanup(func() { SetTransport(nil) })
+
+	if _, err := GetJobConfig(account, "svc-api"); err != nil {
+		t.Fatal(err)
+	}
+	if err := DisableJob(account, "infra"); err != nil {
+		t.Fatal(err)
+	}
+	GetJobConfig(account, "missing")
+
+	lines := strings.Split(strings.TrimSpace(verbose.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("verbose trace has %d lines, want one per request:\n%s", len(lines), verbose.String())
+	}
+	if !strings.Contains(lines[0], "GET "+server.URL+"/job/svc-api/config.xml → 200 (") || !strings.Contains(lines[0], "bytes)") {
+		t.Errorf("verbose line = %q", lines[0])
+	}
+	if !strings.Contains(lines[3], "/job/missing/config.xml → 404") {
+		t.Errorf("verbose line of a failed request = %q", lines[3])
+	}
+
+	trace := debug.String()
+	for _, leak := range []string{"Basic ", jenkinstest.DefaultCrumb} {
+		if strings.Contains(trace, leak) {
+			t.Errorf("debug trace leaks %q", leak)
+		}
+	}
+	if !strings.Contains(trace, "> Authorization: REDACTED") || !strings.Contains(trace, "< Content-Type: application/xml") {
+		t.Errorf("debug trace misses headers:\n%s", trace)
+	}
+	if !strings.Contains(trace, "more bytes]") || strings.Contains(trace, "</project>") {
+		t.Error("long body was not truncated")
+	}
+}
+
+func TestTracerLogsNetworkErrors(t *testing.T) {
+	server, account := newTestServer(t)
+	server.Close()
+	var out bytes.Buffer
+	tracer := NewTracer(http.DefaultTransport)
+	tracer.AddOutput(&out, false)
+	SetTransport(tracer)
+	t.Cleanup(func() { SetTransport(nil) })
+
+	GetJobConfig(account, "svc-api")
+	if !strings.Contains(out.String(), "GET "+server.URL+"/job/svc-api/config.xml → error after") {
+		t.Errorf("trace of a refused connection = %q", out.String())
+	}
+}
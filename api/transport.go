@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+// requestTimeout bounds every request so an unresponsive Jenkins ends in a
+// TimeoutError instead of a hanging command.
+var requestTimeout = 60 * time.Second
+
+var (
+	transportMu sync.RWMutex
+	transport   http.RoundTripper = http.DefaultTransport
+)
+
+// SetTransport routes every request of the api package through rt, e.g. a Recorder
+// or Replayer. A nil rt restores the default transport.
+func SetTransport(rt http.RoundTripper) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	transport = rt
+}
+
+func currentTransport() http.RoundTripper {
+	transportMu.RLock()
+	defer transportMu.RUnlock()
+	return transport
+}
+
+// httpClient returns a client on the current transport carrying the session cookies
+// of the account. Jenkins answers most actions with a 302, so POSTs keep the first
+// response instead of following a relative redirect.
+func httpClient(cfg config.JenkinsConfig, followRedirects bool) *http.Client {
+	client := &http.Client{Transport: currentTransport(), Jar: sessionFor(cfg).jar, Timeout: requestTimeout}
+	if !followRedirects {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return client
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Tree builds the tree query parameter, which makes Jenkins render only the listed
+// fields of an api/json response instead of the whole object graph:
+//
+//	NewTree("name").Nested("lastBuild", NewTree("number", "result"))
+//
+// renders "name,lastBuild[number,result]". A Tree is a value, every method returns
+// a new tree so shared trees can be extended safely.
+type Tree struct {
+	fields []treeField
+}
+
+type treeField struct {
+	name    string
+	nested  *Tree
+	limited bool
+	from    int
+	to      int
+}
+
+// NewTree returns a tree selecting the given fields.
+func NewTree(names ...string) Tree {
+	return Tree{}.Fields(names...)
+}
+
+// Fields adds plain fields to the tree.
+func (t Tree) Fields(names ...string) Tree {
+	fields := slices.Clip(t.fields)
+	for _, name := range names {
+		fields = append(fields, treeField{name: name})
+	}
+	return Tree{fields: fields}
+}
+
+// Nested adds an object or array field of which only the fields of nested are
+// rendered.
+func (t Tree) Nested(name string, nested Tree) Tree {
+	return Tree{fields: append(slices.Clip(t.fields), treeField{name: name, nested: &nested})}
+}
+
+// Range is Nested for an array field, keeping only the elements from index from up
+// to index to excluded, e.g. the latest builds of a job.
+func (t Tree) Range(name string, nested Tree, from, to int) Tree {
+	field := treeField{name: name, nested: &nested, limited: true, from: from, to: to}
+	return Tree{fields: append(slices.Clip(t.fields), field)}
+}
+
+// String renders the tree in the syntax of the tree query parameter.
+func (t Tree) String() string {
+	var builder strings.Builder
+	t.write(&builder)
+	return builder.String()
+}
+
+func (t Tree) write(builder *strings.Builder) {
+	for index, field := range t.fields {
+		if index > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(field.name)
+		if field.nested != nil {
+			builder.WriteByte('[')
+			field.nested.write(builder)
+			builder.WriteByte(']')
+		}
+		if field.limited {
+			builder.WriteString("{" + strconv.Itoa(field.from) + "," + strconv.Itoa(field.to) + "}")
+		}
+	}
+}
+
+// Params returns the query parameters of a request selecting the tree.
+func (t Tree) Params() map[string]string {
+	return map[string]string{"tree": t.String()}
+}
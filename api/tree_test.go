@@ -0,0 +1,145 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+func TestTreeString(t *testing.T) {
+	cases := []struct {
+		tree     Tree
+		expected string
+	}{
+		{NewTree("name", "color"), "name,color"},
+		{NewTree().Nested("views", NewTree("name")), "views[name]"},
+		{NewTree().Range("builds", NewTree("number", "result"), 0, 5), "builds[number,result]{0,5}"},
+		{NewTree().Nested("jobs", NewTree("name").Nested("lastBuild", NewTree("number"))).Fields("views"), "jobs[name,lastBuild[number]],views"},
+		// The change markers of synced workspaces hash what this tree selects.
+		{viewTree(1), "name,jobs[name,fullName,color,nextBuildNumber,property[parameterDefinitions[name,type,choices,allValueItems[values[value]]]]]"},
+	}
+	for _, c := range cases {
+		if got := c.tree.String(); got != c.expected {
+			t.Errorf("tree = %q, want %q", got, c.expected)
+		}
+	}
+}
+
+func TestTreeIsImmutable(t *testing.T) {
+	base := NewTree("number", "result")
+	extended := base.Fields("url")
+	other := base.Fields("building")
+	if base.String() != "number,result" || extended.String() != "number,result,url" || other.String() != "number,result,building" {
+		t.Errorf("trees share fields: %q, %q, %q", base, extended, other)
+	}
+}
+
+func TestTreeQueries(t *testing.T) {
+	server, account := newTestServer(t)
+	server.AddBuild("svc-web", &jenkinstest.Build{Number: 1, Building: true})
+	running, err := GetComputer(account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(running) != 1 || running[0].JobName != "svc-web" || running[0].BuildNumber != 1 {
+		t.Errorf("GetComputer = %+v", running)
+	}
+	request := server.RequestsTo(http.MethodGet, "/computer/api/json")[0]
+	if request.Query.Get("tree") != computerTree.String() || request.Query.Has("depth") {
+		t.Errorf("GetComputer query = %v", request.Query)
+	}
+
+	for _, path := range []string{"/api/json", "/view/all/api/json", "/job/svc-api/api/json", "/crumbIssuer/api/json"} {
+		server.Handle(http.MethodGet, path, 1, func(w http.ResponseWriter, r *http.Request) {
+			if !r.URL.Query().Has("tree") {
+				t.Errorf("%s requested without a tree", path)
+			}
+			w.Write([]byte("{}"))
+		})
+	}
+	GetViews(account)
+	GetViewJob(account, "all")
+	GetJobParams(account, "svc-api")
+	GetCrumb(account)
+
+	// The fake server applies ranges like Jenkins.
+	if recent, err := GetRecentBuilds(account, "svc-api", 1); err != nil || len(recent) != 1 || recent[0].Number != 2 {
+		t.Errorf("GetRecentBuilds(1) = %+v, %v", recent, err)
+	}
+}
+
+// newBenchmarkServer starts a fake Jenkins with a realistic amount of history: jobs
+// with parameters, health reports and builds carrying causes, parameters and commits.
+func newBenchmarkServer(b *testing.B) (*jenkinstest.Server, config.JenkinsConfig) {
+	b.Helper()
+	server := jenkinstest.NewServer()
+	b.Cleanup(server.Close)
+	for jobIndex := range 20 {
+		job := &jenkinstest.Job{
+			Name:     fmt.Sprintf("job-%02d", jobIndex),
+			Choices:  []string{"dev", "test", "staging", "prod"},
+			Branches: []string{"main", "develop", "release/1.0", "release/1.1"},
+			Health:   []jenkinstest.HealthReport{{Score: 80, Description: "Build stability: 1 out of the last 5 builds failed."}},
+		}
+		for number := 1; number <= 30; number++ {
+			job.Builds = append(job.Builds, &jenkinstest.Build{
+				Number: number, Result: "SUCCESS", Duration: 90000, EstimatedDuration: 90000,
+				Timestamp: 1700000000000 + int64(number)*3600000,
+				Params:    map[string]string{"pro": "dev", "tag": "main"},
+				Causes:    []jenkinstest.Cause{{UserId: "tester", UserName: "Tester"}},
+				ChangeSets: []jenkinstest.Change{{
+					CommitId: fmt.Sprintf("%040d", number), Timestamp: 1700000000000, Author: "Alice",
+					Comment: "Update dependencies", AffectedPaths: []string{"go.mod", "go.sum"},
+				}},
+			})
+		}
+		job.Builds[len(job.Builds)-1].Building = true
+		server.AddJob(job)
+	}
+	for range 10 {
+		server.Enqueue("job-00", map[string]string{"pro": "dev"})
+	}
+	return server, config.JenkinsConfig{Name: "bench", BaseApi: server.URL}
+}
+
+// BenchmarkTreeQueries compares the size of the responses the api functions used to
+// request with their tree queries, reported as bytes per response. The fake server
+// renders nested objects fully and filters afterwards, so only the sizes are
+// representative of a real Jenkins, not the timings.
+func BenchmarkTreeQueries(b *testing.B) {
+	_, account := newBenchmarkServer(b)
+	cases := []struct {
+		name string
+		path string
+		full map[string]string
+		tree Tree
+	}{
+		{"GetViews", "/api/json", nil, NewTree().Nested("views", NewTree("name"))},
+		{"GetViewJob", "/view/all/api/json", nil, NewTree().Nested("jobs", NewTree("name"))},
+		{"GetViewStatus", "/view/all/api/json", nil, viewStatusTree},
+		{"GetJobParams", "/job/job-00/api/json", nil, jobParamsTree},
+		{"GetBuildStatus", "/job/job-00/1/api/json", nil, buildStatusTree},
+		{"GetQueue", "/queue/api/json", nil, queueTree},
+		{"GetComputer", "/computer/api/json", map[string]string{"depth": "1"}, computerTree},
+	}
+	for _, benchmark := range cases {
+		run := func(params map[string]string) func(*testing.B) {
+			return func(b *testing.B) {
+				var size int
+				for b.Loop() {
+					resBody, _, _, err := baseReq(account, benchmark.path, params)
+					if err != nil {
+						b.Fatal(err)
+					}
+					size = len(resBody)
+				}
+				b.ReportMetric(float64(size), "B/response")
+			}
+		}
+		b.Run(benchmark.name+"/full", run(benchmark.full))
+		b.Run(benchmark.name+"/tree", run(benchmark.tree.Params()))
+	}
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "alias list|add|remove",
+	Long:  `manage aliases that bind a name to a view, a job and fixed build parameters`,
+}
+
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list aliases",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		if len(workspaceCfg.Aliases) == 0 {
+			color.White("🥚  No aliases defined")
+			return nil
+		}
+		for _, alias := range workspaceCfg.Aliases {
+			color.Cyan("🔖 %s → view: %s, job: %s %s", alias.Name, alias.View, alias.Job, formatParams(alias.Params))
+		}
+		return nil
+	},
+}
+
+var aliasAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "add or replace an alias",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the alias name as argument")
+		}
+		viewName, _ := cmd.Flags().GetString("view")
+		jobName, _ := cmd.Flags().GetString("job")
+		paramPairs, _ := cmd.Flags().GetStringArray("param")
+
+		params, err := util.ParseKeyValues(paramPairs)
+		if err != nil {
+			return err
+		}
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		if jobName == "" {
+			if viewName == "" {
+				viewName = selectView(workspaceCfg)
+				if viewName == "" {
+					return errors.New("no view selected")
+				}
+			}
+			jobName = selectJob(workspaceCfg, viewName)
+			if jobName == "" {
+				return errors.New("no job selected")
+			}
+		}
+
+		alias := config.Alias{Name: args[0], View: viewName, Job: jobName, Params: params}
+		index := slices.IndexFunc(workspaceCfg.Aliases, func(item config.Alias) bool {
+			return item.Name == alias.Name
+		})
+		if index >= 0 {
+			workspaceCfg.Aliases[index] = alias
+		} else {
+			workspaceCfg.Aliases = append(workspaceCfg.Aliases, alias)
+		}
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		color.Green("✅ Alias %s saved", alias.Name)
+		return nil
+	},
+}
+
+var aliasRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "remove an alias",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the alias name as argument")
+		}
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		index := slices.IndexFunc(workspaceCfg.Aliases, func(item config.Alias) bool {
+			return item.Name == args[0]
+		})
+		if index < 0 {
+			color.Yellow("⚠️ Alias %s not found", args[0])
+			return nil
+		}
+		workspaceCfg.Aliases = slices.Delete(workspaceCfg.Aliases, index, index+1)
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		color.Green("✅ Alias %s removed", args[0])
+		return nil
+	},
+}
+
+func findAlias(cfg config.Workspace, name string) (config.Alias, bool) {
+	for _, alias := range cfg.Aliases {
+		if alias.Name == name {
+			return alias, true
+		}
+	}
+	return config.Alias{}, false
+}
+
+// aliasLabel shows an alias with the job it runs, e.g. "deploy-prod → prod/svc-api".
+func aliasLabel(alias config.Alias) string {
+	target := alias.Job
+	if alias.View != "" {
+		target = alias.View + "/" + alias.Job
+	}
+	return alias.Name + " → " + target
+}
+
+func formatParams(params map[string]string) string {
+	if len(params) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, params[key]))
+	}
+	return "(" + strings.Join(pairs, ", ") + ")"
+}
+
+func init() {
+	rootCmd.AddCommand(aliasCmd)
+	aliasCmd.AddCommand(aliasListCmd, aliasAddCmd, aliasRemoveCmd)
+	aliasCmd.PersistentFlags().String("account", "", "account name")
+	aliasAddCmd.Flags().String("view", "", "view name")
+	aliasAddCmd.Flags().String("job", "", "job name")
+	aliasAddCmd.Flags().StringArray("param", nil, "fixed build parameter as key=value (repeatable)")
+}
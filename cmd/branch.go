@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+)
+
+const refreshBranchOption = "🔄 Refresh branch list from git"
+const manualBranchOption = "✏️  Enter branch manually"
+
+// selectBranch prompts for the branch of jobName. Besides the cached branches it
+// offers to refresh the list through the git parameter plugin and to type a branch
+// that Jenkins does not know yet.
+func selectBranch(account config.JenkinsConfig, workspaceCfg *config.Workspace, viewName, jobName string, choices, branches []string) string {
+	items := make([]config.BranchItem, 0, len(branches))
+	for _, branch := range branches {
+		items = append(items, config.BranchItem{Value: branch, Name: branch})
+	}
+	gitBranch, _ := util.CurrentGitBranch()
+
+	for {
+		values := make([]string, 0, len(items))
+		labels := make([]string, 0, len(items)+2)
+		byLabel := make(map[string]string, len(items))
+		labelOf := make(map[string]string, len(items))
+		labels = append(labels, refreshBranchOption, manualBranchOption)
+		for _, item := range items {
+			label := item.Value
+			if item.Name != "" && item.Name != item.Value {
+				label = item.Value + " — " + item.Name
+			}
+			values = append(values, item.Value)
+			labels = append(labels, label)
+			byLabel[label] = item.Value
+			labelOf[item.Value] = label
+		}
+
+		// Inside a repository the checked out branch is offered first.
+		current := labelOf[util.MatchGitBranch(values, gitBranch)]
+		recent := make([]string, 0)
+		for _, value := range getJobRecentBranches(*workspaceCfg, viewName, jobName, values) {
+			recent = append(recent, labelOf[value])
+		}
+
+		selected := util.StrUISelectWithCurrent("Select Branch", labels, current, recent)
+		switch selected {
+		case refreshBranchOption:
+			refreshed, err := api.RefreshGitBranches(account, jobName)
+			if err != nil {
+				color.Yellow("⚠️ Error refreshing branches: %v", err)
+				continue
+			}
+			items = refreshed
+			refreshedValues := make([]string, 0, len(refreshed))
+			for _, item := range refreshed {
+				refreshedValues = append(refreshedValues, item.Value)
+			}
+			if updateWorkspaceParams(workspaceCfg, account.Name, viewName, jobName, choices, refreshedValues) {
+				color.Yellow("⚠️ Workspace params updated.")
+			}
+			color.Cyan("🔄 %d branches loaded", len(refreshed))
+		case manualBranchOption:
+			return util.PromptText("Branch", util.ValidateBranchName)
+		case "":
+			return ""
+		default:
+			return byLabel[selected]
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+var buildCmd = &cobra.Command{
+	Use:   "build [dir]",
+	Short: "build [dir]",
+	Long:  `build the Jenkins job of the git repository in dir (default ".") on the branch checked out there`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		remotes, err := util.GitRemoteURLs(dir)
+		if err != nil {
+			return fmt.Errorf("failed to read git repository: %w", err)
+		}
+		if len(remotes) == 0 {
+			return errors.New("the repository has no remotes to match Jenkins jobs against")
+		}
+		branch, err := util.GitBranch(dir)
+		if err != nil {
+			color.Yellow("⚠️ %v, the branch will be selected manually", err)
+		}
+
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		jobNames := findRepoJobs(account, &workspaceCfg, remotes)
+		var jobName string
+		switch len(jobNames) {
+		case 0:
+			return fmt.Errorf("no job builds %v, run 'jenkins-cli sync' if the job is new", remotes)
+		case 1:
+			jobName = jobNames[0]
+		default:
+			jobName = util.StrUISelect("Select Job", jobNames)
+			if jobName == "" {
+				return errors.New("no job selected")
+			}
+		}
+		viewName := findJobView(workspaceCfg, jobName)
+		color.Cyan("📦 %s → %s on %s", dir, jobName, branch)
+
+		fixed := make(map[string]string)
+		if branch != "" {
+			_, branches, err := api.GetJobParams(account, jobName)
+			if err != nil {
+				return fmt.Errorf("failed to get job parameters: %w", err)
+			}
+			if match := util.MatchGitBranch(branches, branch); match != "" {
+				fixed[config.PARAM_BRANCH] = match
+			} else {
+				color.Yellow("⚠️ Branch %s is not offered by %s, please select one", branch, jobName)
+			}
+		}
+
+		params, err := selectJobParams(account, &workspaceCfg, viewName, jobName, fixed)
+		if err != nil {
+			return err
+		}
+		buildNumber, err := triggerBuild(account, &workspaceCfg, viewName, jobName, params)
+		if err != nil {
+			return err
+		}
+		return notifyWhenFinished(cmd, account, jobName, buildNumber)
+	},
+}
+
+// findRepoJobs returns the jobs whose SCM configuration points at one of remotes.
+// SCM URLs are cached in the workspace and refetched once older than the account TTL.
+func findRepoJobs(account config.JenkinsConfig, workspaceCfg *config.Workspace, remotes []string) []string {
+	wanted := make(map[string]struct{}, len(remotes))
+	for _, remote := range remotes {
+		wanted[util.NormalizeGitURL(remote)] = struct{}{}
+	}
+
+	ttl := util.GetWorkspaceTTL(account)
+	pending := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, view := range util.AllViews(workspaceCfg) {
+		for _, job := range view.Job {
+			if _, ok := seen[job.Name]; ok {
+				continue
+			}
+			seen[job.Name] = struct{}{}
+			if job.ScmCheckedAt.IsZero() || isStale(job.ScmCheckedAt, ttl) {
+				pending = append(pending, job.Name)
+			}
+		}
+	}
+
+	if len(pending) > 0 {
+		bar := util.NewProgressBar("🔎 Reading job SCM config", len(pending))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, defaultSyncWorkers)
+		now := time.Now()
+		for _, jobName := range pending {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-slots }()
+				urls, err := api.GetJobSCMURLs(account, jobName)
+				mu.Lock()
+				defer mu.Unlock()
+				bar.Increment()
+				if err != nil {
+					return
+				}
+				updateJobInWorkspace(workspaceCfg, jobName, func(job *config.Job) {
+					job.ScmUrls = urls
+					job.ScmCheckedAt = now
+				})
+			}()
+		}
+		wg.Wait()
+		bar.Finish()
+		if err := saveWorkspaceFile(account.Name, *workspaceCfg); err != nil {
+			color.Yellow("⚠️ Failed to write workspace config: %v", err)
+		}
+	}
+
+	matches := make(map[string]struct{})
+	for _, view := range util.AllViews(workspaceCfg) {
+		for _, job := range view.Job {
+			for _, scmURL := range job.ScmUrls {
+				if _, ok := wanted[util.NormalizeGitURL(scmURL)]; ok {
+					matches[job.Name] = struct{}{}
+				}
+			}
+		}
+	}
+	jobNames := make([]string, 0, len(matches))
+	for jobName := range matches {
+		jobNames = append(jobNames, jobName)
+	}
+	sort.Strings(jobNames)
+	return jobNames
+}
+
+func init() {
+	rootCmd.AddCommand(buildCmd)
+	buildCmd.Flags().String("account", "", "account name")
+	addNotifyFlag(buildCmd)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "cache clear",
+	Long: `manage the local cache of Jenkins responses such as views, job parameters and crumbs,
+pass --no-cache to any command to bypass it`,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "remove every cached Jenkins response",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := api.ClearCache(util.GetCacheDirPath()); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+		color.Green("🧹 Cache cleared")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
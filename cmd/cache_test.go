@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/util"
+)
+
+func TestCacheClear(t *testing.T) {
+	setupTest(t)
+	entry := filepath.Join(util.GetCacheDirPath(), "account", "entry.yaml")
+	if err := os.MkdirAll(filepath.Dir(entry), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(entry, []byte("body: {}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if output := runCommand(t, "cache", "clear"); !strings.Contains(output, "Cache cleared") {
+		t.Errorf("cache clear output:\n%s", output)
+	}
+	if _, err := os.Stat(util.GetCacheDirPath()); !os.IsNotExist(err) {
+		t.Errorf("cache directory still exists: %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/fatih/color"
 	"github.com/lemonsoul/jenkins-cli/api"
 	"github.com/lemonsoul/jenkins-cli/util"
@@ -11,20 +13,19 @@ var cancelCmd = &cobra.Command{
 	Use:   "cancel",
 	Short: "cancel <queueId>",
 	Long:  `jenkins-cli job cancel`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 1 {
-			color.White("Please provide the job name and build number as arguments.")
-			return
+			return usageError("provide the queue id as argument")
 		}
 		account, err := util.PickAccount("")
 		if err != nil {
-			color.Red("❌ Error loading account configuration: %v", err)
-			return
+			return fmt.Errorf("failed to load account configuration: %w", err)
 		}
-		flag, _ := api.CancelItem(account, args[0])
-		if flag {
-			color.Green("✅ Queue item %s cancelled successfully", args[0])
+		if _, err := api.CancelItem(account, args[0]); err != nil {
+			return fmt.Errorf("failed to cancel queue item %s: %w", args[0], err)
 		}
+		color.Green("✅ Queue item %s cancelled successfully", args[0])
+		return nil
 	},
 }
 
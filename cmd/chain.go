@@ -0,0 +1,241 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+const chainMaxDepth = 10
+const chainBuildScan = 20
+const chainMinInterval = time.Second
+
+type chainNode struct {
+	Build    config.BuildSummary
+	Children []*chainNode
+}
+
+var chainCmd = &cobra.Command{
+	Use:   "chain",
+	Short: "chain <jobName> <build>",
+	Long:  `show the upstream/downstream build chain a build belongs to`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the job name and build number as arguments")
+		}
+		follow, _ := cmd.Flags().GetBool("follow")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		interval = max(interval, chainMinInterval)
+		extraDownstream, _ := cmd.Flags().GetStringSlice("downstream")
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+
+		buildNumber, err := resolveBuildArg(account, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		build, err := api.GetBuildSummary(account, args[0], buildNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get build: %w", err)
+		}
+		root := findChainRoot(account, build)
+
+		for {
+			tree := buildChainTree(account, root, extraDownstream, 0)
+			if follow {
+				fmt.Print("\033[H\033[2J")
+			}
+			printChainNode(tree, "", true, build)
+			if !follow || !chainBuilding(tree) && !chainQueued(account, tree) {
+				break
+			}
+			color.HiBlack("refreshing every %s, press Ctrl+C to stop", interval)
+			time.Sleep(interval)
+			if refreshed, err := api.GetBuildSummary(account, root.JobName, strconv.Itoa(root.Number)); err == nil {
+				root = refreshed
+			}
+		}
+		return nil
+	},
+}
+
+// findChainRoot follows upstream causes to the build that started the chain.
+func findChainRoot(account config.JenkinsConfig, build config.BuildSummary) config.BuildSummary {
+	for range chainMaxDepth {
+		cause, ok := upstreamCause(build)
+		if !ok {
+			break
+		}
+		upstream, err := api.GetBuildSummary(account, cause.UpstreamProject, strconv.Itoa(cause.UpstreamBuild))
+		if err != nil {
+			color.Yellow("⚠️ Error getting upstream build %s #%d: %v", cause.UpstreamProject, cause.UpstreamBuild, err)
+			break
+		}
+		build = upstream
+	}
+	return build
+}
+
+// buildChainTree finds the builds of the downstream projects that were triggered by
+// build. Pipelines starting jobs with the build step do not declare downstream
+// projects, so extra candidate jobs can be passed in.
+func buildChainTree(account config.JenkinsConfig, build config.BuildSummary, extraDownstream []string, depth int) *chainNode {
+	node := &chainNode{Build: build}
+	if depth >= chainMaxDepth {
+		return node
+	}
+	projects, err := api.GetDownstreamProjects(account, build.JobName)
+	if err != nil {
+		color.Yellow("⚠️ Error getting downstream projects of %s: %v", build.JobName, err)
+	}
+	for _, project := range extraDownstream {
+		if project != "" && project != build.JobName {
+			projects = append(projects, project)
+		}
+	}
+	seen := make(map[string]struct{})
+	for _, project := range projects {
+		if _, ok := seen[project]; ok {
+			continue
+		}
+		seen[project] = struct{}{}
+		builds, err := api.GetRecentBuilds(account, project, chainBuildScan)
+		if err != nil {
+			color.Yellow("⚠️ Error getting builds of %s: %v", project, err)
+			continue
+		}
+		for _, candidate := range builds {
+			cause, ok := upstreamCause(candidate)
+			if ok && cause.UpstreamProject == build.JobName && cause.UpstreamBuild == build.Number {
+				node.Children = append(node.Children, buildChainTree(account, candidate, extraDownstream, depth+1))
+			}
+		}
+	}
+	return node
+}
+
+func upstreamCause(build config.BuildSummary) (config.BuildCause, bool) {
+	for _, cause := range build.Causes {
+		if cause.Type == config.CAUSE_UPSTREAM {
+			return cause, true
+		}
+	}
+	return config.BuildCause{}, false
+}
+
+func chainBuilding(node *chainNode) bool {
+	if node.Build.Building {
+		return true
+	}
+	for _, child := range node.Children {
+		if chainBuilding(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// chainQueued reports whether a build triggered by a build of the chain is still
+// waiting in the queue, as downstream builds are once their upstream build finished.
+func chainQueued(account config.JenkinsConfig, node *chainNode) bool {
+	queue, err := api.GetQueue(account)
+	if err != nil {
+		color.Yellow("⚠️ Error getting queue: %v", err)
+		return false
+	}
+	builds := make(map[string]struct{})
+	var collect func(node *chainNode)
+	collect = func(node *chainNode) {
+		builds[node.Build.JobName+"#"+strconv.Itoa(node.Build.Number)] = struct{}{}
+		for _, child := range node.Children {
+			collect(child)
+		}
+	}
+	collect(node)
+	for _, item := range queue {
+		for _, cause := range item.Causes {
+			if _, ok := builds[cause.UpstreamProject+"#"+strconv.Itoa(cause.UpstreamBuild)]; ok && cause.Type == config.CAUSE_UPSTREAM {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func printChainNode(node *chainNode, prefix string, last bool, selected config.BuildSummary) {
+	branch := "├─ "
+	childPrefix := prefix + "│  "
+	if last {
+		branch = "└─ "
+		childPrefix = prefix + "   "
+	}
+	if prefix == "" {
+		branch = ""
+		childPrefix = ""
+	}
+	build := node.Build
+	line := fmt.Sprintf("%s%s%s #%d  %s  %s  %s", prefix, branch, color.CyanString(build.JobName), build.Number,
+		util.ColorizeStatus(buildStatus(build)), formatBuildDuration(build), color.HiBlackString(describeCauses(build.Causes)))
+	if build.JobName == selected.JobName && build.Number == selected.Number {
+		line += color.YellowString("  ◀")
+	}
+	fmt.Println(line)
+	for index, child := range node.Children {
+		printChainNode(child, childPrefix, index == len(node.Children)-1, selected)
+	}
+}
+
+func buildStatus(build config.BuildSummary) string {
+	if build.Building {
+		return "BUILDING"
+	}
+	if build.Result == "" {
+		return "UNKNOWN"
+	}
+	return build.Result
+}
+
+func formatBuildDuration(build config.BuildSummary) string {
+	duration := time.Duration(build.Duration) * time.Millisecond
+	if build.Building && build.Timestamp > 0 {
+		duration = time.Since(time.UnixMilli(build.Timestamp))
+	}
+	return duration.Round(time.Second).String()
+}
+
+func describeCauses(causes []config.BuildCause) string {
+	descriptions := make([]string, 0, len(causes))
+	for _, cause := range causes {
+		switch cause.Type {
+		case config.CAUSE_UPSTREAM:
+			descriptions = append(descriptions, fmt.Sprintf("upstream %s #%d", cause.UpstreamProject, cause.UpstreamBuild))
+		case config.CAUSE_USER:
+			descriptions = append(descriptions, "by "+cause.UserName)
+		case config.CAUSE_SCM:
+			descriptions = append(descriptions, "SCM change")
+		case config.CAUSE_TIMER:
+			descriptions = append(descriptions, "timer")
+		default:
+			descriptions = append(descriptions, cause.ShortDescription)
+		}
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+func init() {
+	rootCmd.AddCommand(chainCmd)
+	chainCmd.Flags().String("account", "", "account name")
+	chainCmd.Flags().BoolP("follow", "f", false, "refresh until all builds of the chain finished")
+	chainCmd.Flags().Duration("interval", 5*time.Second, "refresh interval for --follow, at least 1s")
+	chainCmd.Flags().StringSlice("downstream", nil, "additional jobs to search for downstream builds")
+}
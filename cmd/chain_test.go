@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+func TestChainPipelineUpstream(t *testing.T) {
+	server, account := setupTest(t)
+	server.AddFolder("platform")
+	server.AddJob(&jenkinstest.Job{Name: "platform/release", Builds: []*jenkinstest.Build{{Number: 3, Result: "SUCCESS"}}})
+	server.AddBuild("svc-web", &jenkinstest.Build{Number: 1, Result: "SUCCESS", Causes: []jenkinstest.Cause{{
+		Class:           "org.jenkinsci.plugins.workflow.support.steps.build.BuildUpstreamCause",
+		UpstreamProject: "platform/release",
+		UpstreamBuild:   3,
+	}}})
+
+	build, err := api.GetBuildSummary(account, "svc-web", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := findChainRoot(account, build)
+	if root.JobName != "platform/release" || root.Number != 3 {
+		t.Fatalf("chain root = %s #%d, want platform/release #3", root.JobName, root.Number)
+	}
+	tree := buildChainTree(account, root, []string{"svc-web"}, 0)
+	if len(tree.Children) != 1 || tree.Children[0].Build.JobName != "svc-web" {
+		t.Errorf("downstream of the pipeline = %+v", tree.Children)
+	}
+}
+
+func TestChainQueued(t *testing.T) {
+	server, account := setupTest(t)
+	server.AddBuild("svc-web", &jenkinstest.Build{Number: 1, Result: "SUCCESS"})
+	root := &chainNode{Build: config.BuildSummary{JobName: "svc-web", Number: 1, Result: "SUCCESS"}}
+	if chainQueued(account, root) {
+		t.Error("chain queued with an empty queue")
+	}
+
+	unrelated := server.Enqueue("infra", nil)
+	server.Update(func() { unrelated.Causes = []jenkinstest.Cause{{UpstreamProject: "svc-web", UpstreamBuild: 2}} })
+	if chainQueued(account, root) {
+		t.Error("chain queued for a build of another chain")
+	}
+
+	downstream := server.Enqueue("infra", nil)
+	server.Update(func() { downstream.Causes = []jenkinstest.Cause{{UpstreamProject: "svc-web", UpstreamBuild: 1}} })
+	if !chainQueued(account, root) {
+		t.Error("queued downstream build not found")
+	}
+}
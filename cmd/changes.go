@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/spf13/cobra"
+)
+
+type buildChanges struct {
+	BuildNumber int
+	ChangeSets  []config.ChangeSet
+}
+
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "changes <jobName> [build] [--from N --to M]",
+	Long:  `show the commits of a build, or aggregate the commits of a range of builds`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fromRef, _ := cmd.Flags().GetString("from")
+		toRef, _ := cmd.Flags().GetString("to")
+		markdown, _ := cmd.Flags().GetBool("markdown")
+		if len(args) < 1 {
+			return usageError("provide the job name and build number as arguments")
+		}
+		if len(args) >= 2 {
+			fromRef, toRef = args[1], args[1]
+		}
+		if fromRef == "" || toRef == "" {
+			return usageError("provide a build number or both --from and --to")
+		}
+
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		from, err := resolveBuildRangeEnd(account, args[0], fromRef)
+		if err != nil {
+			return err
+		}
+		to, err := resolveBuildRangeEnd(account, args[0], toRef)
+		if err != nil {
+			return err
+		}
+		if from > to {
+			from, to = to, from
+		}
+
+		builds := make([]buildChanges, 0, to-from+1)
+		seen := make(map[string]struct{})
+		for number := to; number >= from; number-- {
+			changeSets, err := api.GetBuildChangeSets(account, args[0], strconv.Itoa(number))
+			if err != nil {
+				color.Yellow("⚠️ Skipping build #%d: %v", number, err)
+				continue
+			}
+			// A commit can be reported by several builds of the range, keep it once.
+			changeSets = slices.DeleteFunc(changeSets, func(changeSet config.ChangeSet) bool {
+				if changeSet.CommitId == "" {
+					return false
+				}
+				_, duplicate := seen[changeSet.CommitId]
+				seen[changeSet.CommitId] = struct{}{}
+				return duplicate
+			})
+			builds = append(builds, buildChanges{BuildNumber: number, ChangeSets: changeSets})
+		}
+
+		if markdown {
+			fmt.Print(formatChangelogMarkdown(args[0], from, to, builds))
+			return nil
+		}
+		printChanges(builds)
+		return nil
+	},
+}
+
+func resolveBuildRangeEnd(account config.JenkinsConfig, jobName, ref string) (int, error) {
+	buildNumber, err := resolveBuildArg(account, jobName, ref)
+	if err != nil {
+		return 0, err
+	}
+	number, err := strconv.Atoi(buildNumber)
+	if err != nil || number <= 0 {
+		return 0, usageError("invalid build number: %s", ref)
+	}
+	return number, nil
+}
+
+func printChanges(builds []buildChanges) {
+	total := 0
+	for _, build := range builds {
+		color.Cyan("🔁 Build #%d (%d commits)", build.BuildNumber, len(build.ChangeSets))
+		for _, changeSet := range build.ChangeSets {
+			color.White("  %s  %s  %s  %s",
+				color.YellowString(shortSha(changeSet.CommitId)),
+				formatChangeTime(changeSet.Timestamp),
+				color.GreenString(changeSet.AuthorFullName),
+				changeSet.Comment)
+			for _, affectedPath := range changeSet.AffectedPaths {
+				color.HiBlack("      %s", affectedPath)
+			}
+		}
+		total += len(build.ChangeSets)
+	}
+	if total == 0 {
+		color.Yellow("⚠️ No change sets")
+	}
+}
+
+// formatChangelogMarkdown renders the commits as release notes, newest build first.
+func formatChangelogMarkdown(jobName string, from, to int, builds []buildChanges) string {
+	var builder strings.Builder
+	if from == to {
+		fmt.Fprintf(&builder, "## %s #%d\n\n", jobName, from)
+	} else {
+		fmt.Fprintf(&builder, "## %s #%d – #%d\n\n", jobName, from, to)
+	}
+	for _, build := range builds {
+		if len(build.ChangeSets) == 0 {
+			continue
+		}
+		if from != to {
+			fmt.Fprintf(&builder, "### Build #%d\n\n", build.BuildNumber)
+		}
+		for _, changeSet := range build.ChangeSets {
+			fmt.Fprintf(&builder, "- %s (`%s`, %s, %s)\n", changeSet.Comment, shortSha(changeSet.CommitId),
+				changeSet.AuthorFullName, formatChangeTime(changeSet.Timestamp))
+			for _, affectedPath := range changeSet.AffectedPaths {
+				fmt.Fprintf(&builder, "  - `%s`\n", affectedPath)
+			}
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+func shortSha(commitId string) string {
+	if len(commitId) > 7 {
+		return commitId[:7]
+	}
+	return commitId
+}
+
+func formatChangeTime(timestamp string) string {
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || millis <= 0 {
+		return "-"
+	}
+	return time.UnixMilli(millis).Format("2006-01-02 15:04")
+}
+
+func init() {
+	rootCmd.AddCommand(changesCmd)
+	changesCmd.Flags().String("account", "", "account name")
+	changesCmd.Flags().String("from", "", "first build of the range, a number or a reference such as lastSuccessful")
+	changesCmd.Flags().String("to", "", "last build of the range, a number or a reference such as last")
+	changesCmd.Flags().Bool("markdown", false, "print a markdown changelog")
+}
@@ -17,20 +17,17 @@ var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "config account",
 	Long:  `manage jenkins-cli accounts`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		action := util.StrUISelect("Select Action", []string{"Add Account", "Delete Account"})
 		switch action {
 		case "Add Account":
-			if err := addAccount(); err != nil {
-				color.Red("❌ %v", err)
-			}
+			return addAccount()
 		case "Delete Account":
-			if err := deleteAccount(); err != nil {
-				color.Red("❌ %v", err)
-			}
+			return deleteAccount()
 		default:
 			color.Yellow("no action selected")
 		}
+		return nil
 	},
 }
 
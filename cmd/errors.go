@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lemonsoul/jenkins-cli/api"
+)
+
+// Exit codes of jenkins-cli, listed in the root command's help and the README.
+const (
+	exitOK           = 0
+	exitError        = 1
+	exitUsage        = 2
+	exitNotFound     = 3
+	exitUnauthorized = 4
+	exitForbidden    = 5
+	exitCrumb        = 6
+	exitNetwork      = 7
+	exitTimeout      = 8
+)
+
+const exitCodeHelp = `Exit codes:
+  0  success
+  1  any other error
+  2  invalid arguments or flags
+  3  job, view, build or queue item not found
+  4  unauthorized, check the account's username and token
+  5  forbidden, the account lacks a Jenkins permission
+  6  crumb rejected
+  7  Jenkins could not be reached
+  8  request to Jenkins timed out`
+
+// UsageError is a command line the command cannot run with.
+type UsageError struct {
+	Message string
+}
+
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+func usageError(format string, args ...any) error {
+	return &UsageError{Message: fmt.Sprintf(format, args...)}
+}
+
+// exitCode maps an error returned by a command to the documented exit code.
+func exitCode(err error) int {
+	var (
+		usage        *UsageError
+		notFound     *api.NotFoundError
+		unauthorized *api.UnauthorizedError
+		forbidden    *api.ForbiddenError
+		crumb        *api.CrumbError
+		timeout      *api.TimeoutError
+		network      *api.NetworkError
+	)
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &usage) || isCobraUsageError(err):
+		return exitUsage
+	case errors.As(err, &notFound):
+		return exitNotFound
+	case errors.As(err, &unauthorized):
+		return exitUnauthorized
+	case errors.As(err, &forbidden):
+		return exitForbidden
+	case errors.As(err, &crumb):
+		return exitCrumb
+	case errors.As(err, &timeout):
+		return exitTimeout
+	case errors.As(err, &network):
+		return exitNetwork
+	}
+	return exitError
+}
+
+// Cobra reports unknown commands as plain errors, flag errors are turned into
+// UsageError by the root command's flag error func.
+func isCobraUsageError(err error) bool {
+	return strings.HasPrefix(err.Error(), "unknown command")
+}
+
+// PartialError is returned by commands working through several items after each
+// failure has already been reported. Err is the first failure, it decides the exit code.
+type PartialError struct {
+	Failed int
+	Total  int
+	Err    error
+}
+
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d of %d failed", e.Failed, e.Total)
+}
+
+func (e *PartialError) Unwrap() error {
+	return e.Err
+}
+
+// partialError returns nil when nothing failed.
+func partialError(failures []error, total int) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	return &PartialError{Failed: len(failures), Total: total, Err: failures[0]}
+}
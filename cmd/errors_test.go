@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/api"
+)
+
+func TestExitCode(t *testing.T) {
+	cases := []struct {
+		err  error
+		code int
+	}{
+		{nil, exitOK},
+		{errors.New("boom"), exitError},
+		{usageError("provide the job name as argument"), exitUsage},
+		{fmt.Errorf("unknown command %q for %q", "foo", "jenkins-cli"), exitUsage},
+		{fmt.Errorf("failed to get build: %w", &api.NotFoundError{}), exitNotFound},
+		{&api.UnauthorizedError{}, exitUnauthorized},
+		{&api.ForbiddenError{}, exitForbidden},
+		{&api.CrumbError{}, exitCrumb},
+		{&api.NetworkError{Err: errors.New("connection refused")}, exitNetwork},
+		{&api.TimeoutError{}, exitTimeout},
+		{partialError([]error{&api.ForbiddenError{}, &api.NotFoundError{}}, 3), exitForbidden},
+	}
+	for _, c := range cases {
+		if code := exitCode(c.err); code != c.code {
+			t.Errorf("exitCode(%v) = %d, want %d", c.err, code, c.code)
+		}
+	}
+}
+
+func TestSentence(t *testing.T) {
+	cases := map[string]string{
+		"failed to get build: 404": "Failed to get build: 404",
+		"élan":                     "Élan",
+		"--view and --watch":       "--view and --watch",
+		"":                         "",
+	}
+	for message, expected := range cases {
+		if got := sentence(message); got != expected {
+			t.Errorf("sentence(%q) = %q, want %q", message, got, expected)
+		}
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+
+	if _, err := executeCommand(t, "log", "svc-api"); exitCode(err) != exitUsage {
+		t.Errorf("log without build number = %v", err)
+	}
+	if _, err := executeCommand(t, "status", "--bogus"); exitCode(err) != exitUsage {
+		t.Errorf("unknown flag = %v", err)
+	}
+	if _, err := executeCommand(t, "chain", "svc-api", "99", "--account", "default"); exitCode(err) != exitNotFound {
+		t.Errorf("chain of a missing build = %v", err)
+	}
+
+	server.Fail(http.MethodPost, "/view/team/addJobToView", http.StatusForbidden, 1)
+	_, err := executeCommand(t, "view", "add", "team", "svc-web", "infra", "--account", "default")
+	var partial *PartialError
+	if !errors.As(err, &partial) || partial.Failed != 1 || partial.Total != 2 || exitCode(err) != exitForbidden {
+		t.Errorf("view add with one forbidden job = %v", err)
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var favoriteCmd = &cobra.Command{
+	Use:   "favorite",
+	Short: "favorite list|add|remove <jobName>...",
+	Long:  `manage favorite jobs pinned at the top of the job selection`,
+}
+
+var favoriteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list favorite jobs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		if len(workspaceCfg.Favorites) == 0 {
+			color.White("🥚  No favorite jobs")
+			return nil
+		}
+		for _, jobName := range workspaceCfg.Favorites {
+			color.Magenta("★ %s", jobName)
+		}
+		return nil
+	},
+}
+
+var favoriteAddCmd = &cobra.Command{
+	Use:   "add <jobName>...",
+	Short: "pin jobs as favorites",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateFavorites(cmd, args, true)
+	},
+}
+
+var favoriteRemoveCmd = &cobra.Command{
+	Use:   "remove <jobName>...",
+	Short: "unpin favorite jobs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateFavorites(cmd, args, false)
+	},
+}
+
+func updateFavorites(cmd *cobra.Command, jobNames []string, add bool) error {
+	if len(jobNames) == 0 {
+		return usageError("provide at least one job name as argument")
+	}
+	account, workspaceCfg, err := loadAccountWorkspace(cmd)
+	if err != nil {
+		return err
+	}
+	for _, jobName := range jobNames {
+		index := slices.Index(workspaceCfg.Favorites, jobName)
+		if add {
+			if index >= 0 {
+				continue
+			}
+			if _, ok := findJobInWorkspace(workspaceCfg, jobName); !ok {
+				color.Yellow("⚠️ Job %s not found in workspace, run 'jenkins-cli sync' first", jobName)
+				continue
+			}
+			workspaceCfg.Favorites = append(workspaceCfg.Favorites, jobName)
+			color.Green("✅ %s added to favorites", jobName)
+		} else if index >= 0 {
+			workspaceCfg.Favorites = slices.Delete(workspaceCfg.Favorites, index, index+1)
+			color.Green("✅ %s removed from favorites", jobName)
+		}
+	}
+	if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+		return fmt.Errorf("failed to write workspace configuration: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(favoriteCmd)
+	favoriteCmd.AddCommand(favoriteListCmd, favoriteAddCmd, favoriteRemoveCmd)
+	favoriteCmd.PersistentFlags().String("account", "", "account name")
+}
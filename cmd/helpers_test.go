@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// setupTest points HOME at a temporary directory holding a single "default"
+// account for a fake Jenkins with two views and three jobs.
+func setupTest(t *testing.T) (*jenkinstest.Server, config.JenkinsConfig) {
+	t.Helper()
+	server := jenkinstest.NewServer()
+	t.Cleanup(server.Close)
+	server.AddJob(&jenkinstest.Job{
+		Name:     "svc-api",
+		Choices:  []string{"dev", "prod"},
+		Branches: []string{"main", "release"},
+		Health:   []jenkinstest.HealthReport{{Score: 90, Description: "Build stability"}},
+		Builds: []*jenkinstest.Build{
+			{Number: 1, Result: "SUCCESS", Duration: 61000, Timestamp: 1700000000000},
+			{Number: 2, Result: "FAILURE", Duration: 30000, Timestamp: 1700000100000},
+		},
+	})
+	server.AddJob(&jenkinstest.Job{Name: "svc-web", Choices: []string{"dev"}})
+	server.AddJob(&jenkinstest.Job{Name: "infra"})
+	server.AddView("team")
+	server.AddView("team/backend", "svc-api")
+
+	account := config.JenkinsConfig{Name: config.DEFAULT_ACCOUNT_NAME, Username: "tester", Token: "secret", BaseApi: server.URL}
+	t.Setenv("HOME", t.TempDir())
+	writeTestAccounts(t, account)
+	return server, account
+}
+
+func writeTestAccounts(t *testing.T, accounts ...config.JenkinsConfig) {
+	t.Helper()
+	data, err := yaml.Marshal(config.JenkinsConfigFile{Accounts: accounts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configPath := util.GetConfigFilePath()
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadTestWorkspace(t *testing.T) config.Workspace {
+	t.Helper()
+	workspaceCfg, err := util.GetWorkspaceFile(config.DEFAULT_ACCOUNT_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return workspaceCfg
+}
+
+// runCommand executes the command line args and returns what it printed, failing
+// the test when the command fails.
+func runCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	text, err := executeCommand(t, args...)
+	if err != nil {
+		t.Fatalf("%v: %v\n%s", args, err, text)
+	}
+	return text
+}
+
+// executeCommand is runCommand returning the command's error. Flags are reset
+// afterwards since cobra keeps their values between executions.
+func executeCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, output, noColor := os.Stdout, color.Output, color.NoColor
+	os.Stdout, color.Output, color.NoColor = writer, writer, true
+	captured := make(chan string)
+	go func() {
+		var buffer bytes.Buffer
+		io.Copy(&buffer, reader)
+		captured <- buffer.String()
+	}()
+
+	rootCmd.SetArgs(args)
+	executeErr := rootCmd.Execute()
+	if closeErr := closeTraceFile(); executeErr == nil {
+		executeErr = closeErr
+	}
+
+	writer.Close()
+	os.Stdout, color.Output, color.NoColor = stdout, output, noColor
+	text := <-captured
+	resetFlags(rootCmd)
+	return text, executeErr
+}
+
+func resetFlags(command *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	command.Flags().VisitAll(reset)
+	command.PersistentFlags().VisitAll(reset)
+	for _, child := range command.Commands() {
+		resetFlags(child)
+	}
+}
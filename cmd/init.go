@@ -16,8 +16,11 @@ var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "init config",
 	Long:  `init config for jenkins-cli`,
-	Run: func(cmd *cobra.Command, args []string) {
-		baseConfigDir, _ := createFile(config.BASE_CONFIG_DIR)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		baseConfigDir, err := createFile(config.BASE_CONFIG_DIR)
+		if err != nil {
+			return err
+		}
 		var (
 			accountName string
 			username    string
@@ -31,8 +34,7 @@ var initCmd = &cobra.Command{
 
 		cfgFile, err := readConfigFileShared(baseConfigDir)
 		if err != nil {
-			color.Red("failed to read config file", err)
-			return
+			return fmt.Errorf("failed to read config file: %w", err)
 		}
 		for {
 			if accountName == "" {
@@ -44,10 +46,10 @@ var initCmd = &cobra.Command{
 				accountName = config.DEFAULT_ACCOUNT_NAME
 			}
 			if hasAccountNameShared(cfgFile.Accounts, accountName) {
-				color.Yellow("account name already exists: %s", accountName)
 				if cmd.Flags().Changed("account") {
-					return
+					return fmt.Errorf("account name already exists: %s", accountName)
 				}
+				color.Yellow("account name already exists: %s", accountName)
 				accountName = ""
 				continue
 			}
@@ -77,15 +79,14 @@ var initCmd = &cobra.Command{
 		})
 		data, _ := yaml.Marshal(&cfgFile)
 		if err := os.WriteFile(baseConfigDir, data, 0644); err != nil {
-			color.Red("failed to write config file", err)
-			return
+			return fmt.Errorf("failed to write config file: %w", err)
 		}
 
 		workspacePath := util.GetWorkspaceFilePathByName(accountName)
 		if err := createFileByFullPath(workspacePath); err != nil {
-			color.Red("failed to create workspace file", err)
-			return
+			return fmt.Errorf("failed to create workspace file: %w", err)
 		}
+		return nil
 	},
 }
 
@@ -103,12 +104,10 @@ func createFile(path string) (dir string, err error) {
 		color.Yellow("config file already exists")
 	} else {
 		if err := os.MkdirAll(filepath.Dir(fileDir), os.ModePerm); err != nil {
-			color.Red("failed to create config dir", err)
-			return "", err
+			return "", fmt.Errorf("failed to create config dir: %w", err)
 		}
 		if _, err := os.Create(fileDir); err != nil {
-			color.Red("failed to create config file")
-			return "", err
+			return "", fmt.Errorf("failed to create config file: %w", err)
 		}
 	}
 	return fileDir, nil
@@ -120,12 +119,10 @@ func createFileByFullPath(filePath string) error {
 		return nil
 	}
 	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
-		color.Red("failed to create config dir", err)
-		return err
+		return fmt.Errorf("failed to create config dir: %w", err)
 	}
 	if _, err := os.Create(filePath); err != nil {
-		color.Red("failed to create config file")
-		return err
+		return fmt.Errorf("failed to create config file: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+var jobCmd = &cobra.Command{
+	Use:   "job",
+	Short: "job config|create|copy|enable|disable|delete|rename",
+	Long:  `manage job definitions`,
+}
+
+var jobConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "config get|diff|apply",
+	Long:  `read and update the config.xml of a job`,
+}
+
+var jobConfigGetCmd = &cobra.Command{
+	Use:   "get <jobName>",
+	Short: "download the config.xml of a job",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the job name as argument")
+		}
+		output, _ := cmd.Flags().GetString("output")
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		configXml, err := api.GetJobConfig(account, args[0])
+		if err != nil {
+			return fmt.Errorf("failed to get job config: %w", err)
+		}
+		if output == "" {
+			fmt.Print(configXml)
+			return nil
+		}
+		if err := os.WriteFile(output, []byte(configXml), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		color.Green("✅ Config of %s written to %s", args[0], output)
+		return nil
+	},
+}
+
+var jobConfigDiffCmd = &cobra.Command{
+	Use:   "diff <jobName> <file>",
+	Short: "show the differences between the job config and a local file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the job name and config file as arguments")
+		}
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		diff, err := diffJobConfig(account, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if !util.HasChanges(diff) {
+			color.Green("✅ %s matches %s", args[1], args[0])
+			return nil
+		}
+		fmt.Print(util.FormatDiff(diff, args[0], args[1]))
+		return nil
+	},
+}
+
+var jobConfigApplyCmd = &cobra.Command{
+	Use:   "apply <jobName> <file>",
+	Short: "replace the job config with a local file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the job name and config file as arguments")
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		diff, err := diffJobConfig(account, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		if !util.HasChanges(diff) {
+			color.Green("✅ %s is already up to date", args[0])
+			return nil
+		}
+		fmt.Print(util.FormatDiff(diff, args[0], args[1]))
+		if !yes && !util.Confirm(fmt.Sprintf("Apply these changes to %s", args[0])) {
+			color.Yellow("⚠️ Aborted")
+			return nil
+		}
+		configXml, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[1], err)
+		}
+		if err := api.UpdateJobConfig(account, args[0], string(configXml)); err != nil {
+			return fmt.Errorf("failed to update job config: %w", err)
+		}
+		color.Green("✅ Config of %s updated", args[0])
+		return nil
+	},
+}
+
+var jobCreateCmd = &cobra.Command{
+	Use:   "create <jobName> <file>",
+	Short: "create a job from a config.xml file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the job name and config file as arguments")
+		}
+		configXml, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[1], err)
+		}
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		if err := api.CreateJob(account, args[0], string(configXml)); err != nil {
+			return fmt.Errorf("failed to create job: %w", err)
+		}
+		color.Green("✅ Job %s created, run 'jenkins-cli sync' to add it to the workspace", args[0])
+		return nil
+	},
+}
+
+var jobCopyCmd = &cobra.Command{
+	Use:   "copy <fromJob> <jobName>",
+	Short: "create a job as a copy of another job",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the source and new job names as arguments")
+		}
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		if err := api.CopyJob(account, args[0], args[1]); err != nil {
+			return fmt.Errorf("failed to copy job: %w", err)
+		}
+		color.Green("✅ Job %s copied to %s, run 'jenkins-cli sync' to add it to the workspace", args[0], args[1])
+		return nil
+	},
+}
+
+// diffJobConfig compares the config.xml of jobName with a local file.
+func diffJobConfig(account config.JenkinsConfig, jobName, file string) ([]util.DiffLine, error) {
+	local, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	remote, err := api.GetJobConfig(account, jobName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job config: %w", err)
+	}
+	return util.DiffLines(remote, string(local)), nil
+}
+
+func init() {
+	rootCmd.AddCommand(jobCmd)
+	jobCmd.AddCommand(jobConfigCmd, jobCreateCmd, jobCopyCmd)
+	jobConfigCmd.AddCommand(jobConfigGetCmd, jobConfigDiffCmd, jobConfigApplyCmd)
+	jobCmd.PersistentFlags().String("account", "", "account name")
+	jobConfigGetCmd.Flags().StringP("output", "o", "", "write to file instead of stdout")
+	jobConfigApplyCmd.Flags().BoolP("yes", "y", false, "apply without confirmation")
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+type jobAction struct {
+	Verb  string
+	Past  string
+	Apply func(account config.JenkinsConfig, jobName string) error
+}
+
+var jobEnableCmd = &cobra.Command{
+	Use:   "enable [pattern...]",
+	Short: "enable the jobs matching glob patterns or a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runJobAction(cmd, args, jobAction{Verb: "enable", Past: "enabled", Apply: api.EnableJob})
+	},
+}
+
+var jobDisableCmd = &cobra.Command{
+	Use:   "disable [pattern...]",
+	Short: "disable the jobs matching glob patterns or a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runJobAction(cmd, args, jobAction{Verb: "disable", Past: "disabled", Apply: api.DisableJob})
+	},
+}
+
+var jobDeleteCmd = &cobra.Command{
+	Use:   "delete [pattern...]",
+	Short: "delete the jobs matching glob patterns or a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runJobAction(cmd, args, jobAction{Verb: "delete", Past: "deleted", Apply: api.DeleteJob})
+	},
+}
+
+var jobRenameCmd = &cobra.Command{
+	Use:   "rename <jobName> <newName>",
+	Short: "rename a job",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the job name and the new name as arguments")
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		oldName, newName := args[0], args[1]
+		if _, exists := findJobInWorkspace(workspaceCfg, newName); exists {
+			return fmt.Errorf("job %s already exists", newName)
+		}
+		color.Cyan("✏️  %s → %s", oldName, newName)
+		if dryRun {
+			color.White("Dry run, nothing changed.")
+			return nil
+		}
+		if !yes && !util.Confirm(fmt.Sprintf("Rename %s to %s", oldName, newName)) {
+			color.Yellow("⚠️ Aborted")
+			return nil
+		}
+		if err := api.RenameJob(account, oldName, newName); err != nil {
+			return fmt.Errorf("failed to rename job: %w", err)
+		}
+		renameJobInWorkspace(&workspaceCfg, oldName, newName)
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		color.Green("✅ Job %s renamed to %s", oldName, newName)
+		return nil
+	},
+}
+
+// runJobAction applies action to every job selected by the patterns and --view,
+// after showing the selection and asking for confirmation.
+func runJobAction(cmd *cobra.Command, patterns []string, action jobAction) error {
+	viewName, _ := cmd.Flags().GetString("view")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if len(patterns) == 0 && viewName == "" {
+		return usageError("provide job name patterns as arguments or a view with --view")
+	}
+	account, workspaceCfg, err := loadAccountWorkspace(cmd)
+	if err != nil {
+		return err
+	}
+	jobNames, err := selectJobsByPattern(workspaceCfg, viewName, patterns)
+	if err != nil {
+		return err
+	}
+	if len(jobNames) == 0 {
+		color.Yellow("⚠️ No jobs match the selection")
+		return nil
+	}
+
+	color.Cyan("🎯 Jobs to %s (%d):", action.Verb, len(jobNames))
+	for _, jobName := range jobNames {
+		color.White("  %s", jobName)
+	}
+	if dryRun {
+		color.White("Dry run, nothing changed.")
+		return nil
+	}
+	if !yes && !util.Confirm(fmt.Sprintf("%s %d jobs", strings.ToUpper(action.Verb[:1])+action.Verb[1:], len(jobNames))) {
+		color.Yellow("⚠️ Aborted")
+		return nil
+	}
+
+	done := make([]string, 0, len(jobNames))
+	failures := make([]error, 0)
+	for _, jobName := range jobNames {
+		if err := action.Apply(account, jobName); err != nil {
+			color.Red("❌ Error trying to %s %s: %v", action.Verb, jobName, err)
+			failures = append(failures, err)
+			continue
+		}
+		done = append(done, jobName)
+		color.Green("✅ %s %s", jobName, action.Past)
+	}
+	if len(done) == 0 {
+		return partialError(failures, len(jobNames))
+	}
+
+	switch action.Verb {
+	case "delete":
+		pruneJobsFromWorkspace(&workspaceCfg, done)
+	case "disable":
+		for _, jobName := range done {
+			updateJobInWorkspace(&workspaceCfg, jobName, func(job *config.Job) { job.Color = "disabled" })
+		}
+	case "enable":
+		for _, jobName := range done {
+			// Jenkins reports a disabled job without its last result, the next sync
+			// brings it back.
+			updateJobInWorkspace(&workspaceCfg, jobName, func(job *config.Job) {
+				if job.Color == "disabled" {
+					job.Color = ""
+				}
+			})
+		}
+	}
+	if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+		return fmt.Errorf("failed to write workspace configuration: %w", err)
+	}
+	return partialError(failures, len(jobNames))
+}
+
+// selectJobsByPattern matches glob patterns against the workspace jobs, limited to
+// viewName when given. A pattern without wildcards is taken as a job name even when
+// the workspace does not know it yet.
+func selectJobsByPattern(cfg config.Workspace, viewName string, patterns []string) ([]string, error) {
+	candidates := make([]string, 0)
+	viewFound := viewName == ""
+	for _, view := range util.AllViews(&cfg) {
+		if viewName != "" && view.Name != viewName {
+			continue
+		}
+		viewFound = true
+		for _, job := range view.Job {
+			if !slices.Contains(candidates, job.Name) {
+				candidates = append(candidates, job.Name)
+			}
+		}
+	}
+	if !viewFound {
+		return nil, fmt.Errorf("view %s not found in workspace", viewName)
+	}
+	if len(patterns) == 0 {
+		slices.Sort(candidates)
+		return candidates, nil
+	}
+
+	selected := make([]string, 0)
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		if !strings.ContainsAny(pattern, "*?[") {
+			if viewName == "" || slices.Contains(candidates, pattern) {
+				selected = append(selected, pattern)
+			}
+			continue
+		}
+		for _, jobName := range candidates {
+			if matched, _ := path.Match(pattern, jobName); matched {
+				selected = append(selected, jobName)
+			}
+		}
+	}
+	slices.Sort(selected)
+	return slices.Compact(selected), nil
+}
+
+// pruneJobsFromWorkspace drops deleted jobs from the views, recent jobs and favorites.
+func pruneJobsFromWorkspace(cfg *config.Workspace, jobNames []string) {
+	removed := func(name string) bool { return slices.Contains(jobNames, name) }
+	for _, view := range util.AllViews(cfg) {
+		view.Job = slices.DeleteFunc(view.Job, func(job config.Job) bool { return removed(job.Name) })
+		view.RecentJobs = slices.DeleteFunc(view.RecentJobs, removed)
+	}
+	cfg.Favorites = slices.DeleteFunc(cfg.Favorites, removed)
+	for _, alias := range cfg.Aliases {
+		if removed(alias.Job) {
+			color.Yellow("⚠️ Alias %s refers to deleted job %s", alias.Name, alias.Job)
+		}
+	}
+	for _, preset := range cfg.Presets {
+		if removed(preset.Job) {
+			color.Yellow("⚠️ Preset %s refers to deleted job %s", preset.Name, preset.Job)
+		}
+	}
+}
+
+// renameJobInWorkspace keeps the cached params and recents of a renamed job.
+func renameJobInWorkspace(cfg *config.Workspace, oldName, newName string) {
+	rename := func(name string) string {
+		if name == oldName {
+			return newName
+		}
+		return name
+	}
+	updateJobInWorkspace(cfg, oldName, func(job *config.Job) { job.Name = newName })
+	for _, view := range util.AllViews(cfg) {
+		for recentIndex := range view.RecentJobs {
+			view.RecentJobs[recentIndex] = rename(view.RecentJobs[recentIndex])
+		}
+	}
+	for index := range cfg.Favorites {
+		cfg.Favorites[index] = rename(cfg.Favorites[index])
+	}
+	for index := range cfg.Aliases {
+		cfg.Aliases[index].Job = rename(cfg.Aliases[index].Job)
+	}
+	for index := range cfg.Presets {
+		cfg.Presets[index].Job = rename(cfg.Presets[index].Job)
+	}
+}
+
+func init() {
+	jobCmd.AddCommand(jobEnableCmd, jobDisableCmd, jobDeleteCmd, jobRenameCmd)
+	for _, command := range []*cobra.Command{jobEnableCmd, jobDisableCmd, jobDeleteCmd} {
+		command.Flags().String("view", "", "select the jobs of this view")
+	}
+	for _, command := range []*cobra.Command{jobEnableCmd, jobDisableCmd, jobDeleteCmd, jobRenameCmd} {
+		command.Flags().Bool("dry-run", false, "show what would change without doing it")
+		command.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+	}
+}
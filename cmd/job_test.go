@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+func TestSelectJobsByPattern(t *testing.T) {
+	workspaceCfg := config.Workspace{Views: []config.View{
+		{Name: "all", Job: []config.Job{{Name: "svc-api"}, {Name: "svc-web"}, {Name: "infra"}}},
+		{Name: "team", Views: []config.View{{Name: "team/backend", Job: []config.Job{{Name: "svc-api"}}}}},
+	}}
+	cases := []struct {
+		view     string
+		patterns []string
+		expected []string
+	}{
+		{"", []string{"svc-*"}, []string{"svc-api", "svc-web"}},
+		{"", []string{"svc-api", "svc-*"}, []string{"svc-api", "svc-web"}},
+		{"", []string{"unknown"}, []string{"unknown"}},
+		{"team/backend", nil, []string{"svc-api"}},
+		{"team/backend", []string{"svc-web"}, []string{}},
+	}
+	for _, testCase := range cases {
+		selected, err := selectJobsByPattern(workspaceCfg, testCase.view, testCase.patterns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(selected, testCase.expected) {
+			t.Errorf("selectJobsByPattern(%q, %v) = %v, want %v", testCase.view, testCase.patterns, selected, testCase.expected)
+		}
+	}
+	if _, err := selectJobsByPattern(workspaceCfg, "missing", nil); err == nil {
+		t.Error("selecting from an unknown view succeeded")
+	}
+	if _, err := selectJobsByPattern(workspaceCfg, "", []string{"["}); err == nil {
+		t.Error("an invalid pattern was accepted")
+	}
+}
+
+func TestJobDisableEnable(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+
+	output := runCommand(t, "job", "disable", "svc-*", "--dry-run", "--account", "default")
+	if !strings.Contains(output, "svc-api") || server.Job("svc-api").Disabled {
+		t.Fatalf("dry run changed jobs:\n%s", output)
+	}
+
+	runCommand(t, "job", "disable", "svc-*", "-y", "--account", "default")
+	if !server.Job("svc-api").Disabled || !server.Job("svc-web").Disabled || server.Job("infra").Disabled {
+		t.Fatal("wrong jobs disabled")
+	}
+	workspaceCfg := loadTestWorkspace(t)
+	if job, _ := findJobInWorkspace(workspaceCfg, "svc-web"); job.Color != "disabled" {
+		t.Errorf("workspace color = %q", job.Color)
+	}
+
+	runCommand(t, "job", "enable", "--view", "team/backend", "-y", "--account", "default")
+	if server.Job("svc-api").Disabled || !server.Job("svc-web").Disabled {
+		t.Error("enable did not follow the view")
+	}
+	if job, _ := findJobInWorkspace(loadTestWorkspace(t), "svc-api"); job.Color != "" {
+		t.Errorf("workspace color after enable = %q", job.Color)
+	}
+}
+
+func TestJobRenameAndDelete(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+	workspaceCfg := loadTestWorkspace(t)
+	workspaceCfg.Favorites = []string{"svc-web"}
+	workspaceCfg.Aliases = []config.Alias{{Name: "web", Job: "svc-web"}}
+	if err := saveWorkspaceFile(config.DEFAULT_ACCOUNT_NAME, workspaceCfg); err != nil {
+		t.Fatal(err)
+	}
+
+	runCommand(t, "job", "rename", "svc-web", "web", "-y", "--account", "default")
+	if server.Job("web") == nil {
+		t.Fatal("job was not renamed on the server")
+	}
+	workspaceCfg = loadTestWorkspace(t)
+	if _, ok := findJobInWorkspace(workspaceCfg, "web"); !ok || workspaceCfg.Favorites[0] != "web" || workspaceCfg.Aliases[0].Job != "web" {
+		t.Errorf("workspace after rename = %+v", workspaceCfg)
+	}
+
+	output := runCommand(t, "job", "delete", "web", "-y", "--account", "default")
+	if !strings.Contains(output, "Alias web refers to deleted job web") {
+		t.Errorf("no warning about the alias:\n%s", output)
+	}
+	workspaceCfg = loadTestWorkspace(t)
+	if _, ok := findJobInWorkspace(workspaceCfg, "web"); ok || len(workspaceCfg.Favorites) != 0 {
+		t.Errorf("workspace after delete = %+v", workspaceCfg)
+	}
+	if server.Job("web") != nil {
+		t.Error("job was not deleted on the server")
+	}
+}
+
+func TestJobConfigDiffAndApply(t *testing.T) {
+	server, _ := setupTest(t)
+	original := server.Job("infra").Config
+	file := filepath.Join(t.TempDir(), "infra.xml")
+	runCommand(t, "job", "config", "get", "infra", "-o", file, "--account", "default")
+	if data, _ := os.ReadFile(file); string(data) != original {
+		t.Fatalf("saved config = %q", data)
+	}
+	if output := runCommand(t, "job", "config", "diff", "infra", file, "--account", "default"); !strings.Contains(output, "matches") {
+		t.Errorf("diff of an unchanged file:\n%s", output)
+	}
+
+	changed := strings.Replace(original, "<description>infra</description>", "<description>Infrastructure</description>", 1)
+	os.WriteFile(file, []byte(changed), 0644)
+	output := runCommand(t, "job", "config", "diff", "infra", file, "--account", "default")
+	if !strings.Contains(output, "-  <description>infra</description>") || !strings.Contains(output, "+  <description>Infrastructure</description>") {
+		t.Errorf("diff output:\n%s", output)
+	}
+	runCommand(t, "job", "config", "apply", "infra", file, "-y", "--account", "default")
+	if server.Job("infra").Config != changed {
+		t.Errorf("applied config = %q", server.Job("infra").Config)
+	}
+}
+
+func TestJobCopy(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+	runCommand(t, "job", "copy", "svc-api", "svc-api-2", "--account", "default")
+	if job := server.Job("svc-api-2"); job == nil || !reflect.DeepEqual(job.Branches, []string{"main", "release"}) {
+		t.Fatalf("copied job = %+v", job)
+	}
+}
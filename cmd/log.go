@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 	"time"
 
@@ -12,26 +13,27 @@ import (
 
 var logCmd = &cobra.Command{
 	Use:   "log",
-	Short: "log <jobName> <buildNumber>",
+	Short: "log <jobName> <build>",
 	Long:  `task log`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 2 {
-			color.White("Please provide the job name and build number as arguments.")
-			return
+			return usageError("provide the job name and build number as arguments")
 		}
 		account, err := util.PickAccount("")
 		if err != nil {
-			color.Red("❌ Error loading account configuration: %v", err)
-			return
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		buildNumber, err := resolveBuildArg(account, args[0], args[1])
+		if err != nil {
+			return err
 		}
 		var logText string
 		var moreData bool
 		var textSize int
 
-		logText, moreData, textSize, err = api.GetTextLog(account, args[0], args[1], nil)
+		logText, moreData, textSize, err = api.GetTextLog(account, args[0], buildNumber, nil)
 		if err != nil {
-			color.Red("❌ Error getting log: %v", err)
-			return
+			return fmt.Errorf("failed to get log: %w", err)
 		}
 
 		if textSize == -1 || !moreData {
@@ -41,14 +43,14 @@ var logCmd = &cobra.Command{
 		} else {
 			printLogLine(logText, 20*time.Millisecond)
 			for moreData {
-				logText, moreData, textSize, err = api.GetTextLog(account, args[0], args[1], &textSize)
+				logText, moreData, textSize, err = api.GetTextLog(account, args[0], buildNumber, &textSize)
 				if err != nil {
-					color.Red("❌ Error getting more log data: %v", err)
-					break
+					return fmt.Errorf("failed to get more log data: %w", err)
 				}
 				printLogLine(logText, 20*time.Millisecond)
 			}
 		}
+		return nil
 	},
 }
 
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+)
+
+type jobEntry struct {
+	Name     string
+	Views    []string
+	Color    string
+	Favorite bool
+	Recent   bool
+}
+
+// selectGlobalJob searches the aliases and jobs of every view at once. The query is
+// ranked with fuzzy matching; an empty query lists aliases, favorites, then recent
+// jobs, then the rest. It returns the first view containing the selected job together
+// with the job name, or the selected alias.
+func selectGlobalJob(cfg config.Workspace) (string, string, *config.Alias) {
+	entries := collectJobEntries(cfg)
+	if len(entries) == 0 {
+		color.Yellow("⚠️ No jobs in workspace, run 'jenkins-cli sync' first")
+		return "", "", nil
+	}
+
+	query := util.PromptText("Search job (fuzzy, empty to list all)", nil)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	ranked := util.FuzzyRank(names, query)
+	aliasNames := make([]string, 0, len(cfg.Aliases))
+	for _, alias := range cfg.Aliases {
+		aliasNames = append(aliasNames, alias.Name)
+	}
+	rankedAliases := util.FuzzyRank(aliasNames, query)
+	if len(ranked) == 0 && len(rankedAliases) == 0 {
+		color.Yellow("⚠️ No job matches %q", query)
+		return "", "", nil
+	}
+
+	items := make([]util.JobSelectItem, 0, len(rankedAliases)+len(ranked))
+	for _, index := range rankedAliases {
+		alias := cfg.Aliases[index]
+		status := "-"
+		if jobIndex := slices.IndexFunc(entries, func(entry jobEntry) bool { return entry.Name == alias.Job }); jobIndex >= 0 {
+			status = util.JobColorStatus(entries[jobIndex].Color)
+		}
+		items = append(items, util.JobSelectItem{
+			Name:    alias.Name,
+			Views:   alias.View,
+			Status:  status,
+			Display: color.GreenString("⚡ %s", aliasLabel(alias)) + "  " + color.HiBlackString(formatParams(alias.Params)),
+		})
+	}
+	for _, index := range ranked {
+		entry := entries[index]
+		status := util.JobColorStatus(entry.Color)
+		prefix := ""
+		switch {
+		case entry.Favorite:
+			prefix = color.MagentaString("★ ")
+		case entry.Recent:
+			prefix = color.YellowString("↺ ")
+		}
+		items = append(items, util.JobSelectItem{
+			Name:    entry.Name,
+			Views:   strings.Join(entry.Views, ", "),
+			Status:  status,
+			Display: fmt.Sprintf("%s%s  %s  %s", prefix, color.CyanString(entry.Name), util.ColorizeStatus(status), color.HiBlackString("[%s]", strings.Join(entry.Views, ", "))),
+		})
+	}
+	selected := util.JobUISelect("Select Job", items)
+	if selected < 0 {
+		return "", "", nil
+	}
+	if selected < len(rankedAliases) {
+		return "", "", &cfg.Aliases[rankedAliases[selected]]
+	}
+	entry := entries[ranked[selected-len(rankedAliases)]]
+	return entry.Views[0], entry.Name, nil
+}
+
+// collectJobEntries deduplicates the jobs of all views, ordered favorites first,
+// then recent jobs, then alphabetically.
+func collectJobEntries(cfg config.Workspace) []jobEntry {
+	favorites := util.BuildAllowSet(cfg.Favorites)
+	recent := make(map[string]struct{})
+	for _, view := range util.AllViews(&cfg) {
+		for _, jobName := range view.RecentJobs {
+			recent[jobName] = struct{}{}
+		}
+	}
+
+	byName := make(map[string]*jobEntry)
+	entries := make([]*jobEntry, 0)
+	for _, view := range util.AllViews(&cfg) {
+		for _, job := range view.Job {
+			entry, ok := byName[job.Name]
+			if !ok {
+				_, favorite := favorites[job.Name]
+				_, isRecent := recent[job.Name]
+				entry = &jobEntry{Name: job.Name, Color: job.Color, Favorite: favorite, Recent: isRecent}
+				byName[job.Name] = entry
+				entries = append(entries, entry)
+			}
+			if !slices.Contains(entry.Views, view.Name) {
+				entry.Views = append(entry.Views, view.Name)
+			}
+		}
+	}
+
+	rank := func(entry *jobEntry) int {
+		switch {
+		case entry.Favorite:
+			return 0
+		case entry.Recent:
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if rank(entries[i]) != rank(entries[j]) {
+			return rank(entries[i]) < rank(entries[j])
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	result := make([]jobEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, *entry)
+	}
+	return result
+}
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+type presetExport struct {
+	Presets []config.Preset `yaml:"presets"`
+}
+
+var presetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "preset list|create|edit|remove|export|run",
+	Long:  `manage named build presets that capture a job and its full parameter set`,
+}
+
+var presetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list presets",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		if len(workspaceCfg.Presets) == 0 {
+			color.White("🥚  No presets defined")
+			return nil
+		}
+		for _, preset := range workspaceCfg.Presets {
+			color.Cyan("📌 %s → job: %s %s", preset.Name, preset.Job, formatParams(preset.Params))
+			if preset.Source != "" {
+				color.White("   created from build %s", preset.Source)
+			}
+		}
+		return nil
+	},
+}
+
+var presetCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "create a preset from flags, a previous build or an interactive selection",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the preset name as argument")
+		}
+		viewName, _ := cmd.Flags().GetString("view")
+		jobName, _ := cmd.Flags().GetString("job")
+		fromBuild, _ := cmd.Flags().GetString("from-build")
+		paramPairs, _ := cmd.Flags().GetStringArray("param")
+
+		overrides, err := util.ParseKeyValues(paramPairs)
+		if err != nil {
+			return err
+		}
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		if _, exists := findPreset(workspaceCfg, args[0]); exists {
+			return fmt.Errorf("preset %s already exists, use 'jenkins-cli preset edit'", args[0])
+		}
+		if jobName == "" {
+			if viewName == "" {
+				viewName = selectView(workspaceCfg)
+				if viewName == "" {
+					return errors.New("no view selected")
+				}
+			}
+			jobName = selectJob(workspaceCfg, viewName)
+			if jobName == "" {
+				return errors.New("no job selected")
+			}
+		}
+
+		preset := config.Preset{Name: args[0], View: viewName, Job: jobName}
+		switch {
+		case fromBuild != "":
+			buildNumber, err := resolveBuildArg(account, jobName, fromBuild)
+			if err != nil {
+				return err
+			}
+			params, err := api.GetBuildParameters(account, jobName, buildNumber)
+			if err != nil {
+				return fmt.Errorf("failed to get build parameters: %w", err)
+			}
+			preset.Params = params
+			preset.Source = jobName + "#" + buildNumber
+		case len(overrides) > 0:
+			preset.Params = make(map[string]string)
+		default:
+			params, err := selectJobParams(account, &workspaceCfg, viewName, jobName, nil)
+			if err != nil {
+				return err
+			}
+			preset.Params = params
+		}
+		for key, value := range overrides {
+			preset.Params[key] = value
+		}
+
+		workspaceCfg.Presets = append(workspaceCfg.Presets, preset)
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		color.Green("✅ Preset %s saved %s", preset.Name, formatParams(preset.Params))
+		return nil
+	},
+}
+
+var presetEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "change a preset with flags, or in $EDITOR when no flag is given",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the preset name as argument")
+		}
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		index := slices.IndexFunc(workspaceCfg.Presets, func(item config.Preset) bool {
+			return item.Name == args[0]
+		})
+		if index < 0 {
+			return fmt.Errorf("preset %s not found", args[0])
+		}
+		preset := workspaceCfg.Presets[index]
+
+		flagEdit := slices.ContainsFunc([]string{"view", "job", "param", "unset"}, cmd.Flags().Changed)
+		if !flagEdit {
+			edited, err := editPresetInEditor(preset)
+			if err != nil {
+				return err
+			}
+			preset = edited
+		} else {
+			paramPairs, _ := cmd.Flags().GetStringArray("param")
+			unsetKeys, _ := cmd.Flags().GetStringArray("unset")
+			params, err := util.ParseKeyValues(paramPairs)
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("view") {
+				preset.View, _ = cmd.Flags().GetString("view")
+			}
+			if cmd.Flags().Changed("job") {
+				preset.Job, _ = cmd.Flags().GetString("job")
+			}
+			if preset.Params == nil {
+				preset.Params = make(map[string]string)
+			}
+			for key, value := range params {
+				preset.Params[key] = value
+			}
+			for _, key := range unsetKeys {
+				delete(preset.Params, key)
+			}
+		}
+		if preset.Name == "" || preset.Job == "" {
+			return errors.New("preset name and job are required")
+		}
+		if _, exists := findPreset(workspaceCfg, preset.Name); exists && preset.Name != args[0] {
+			return fmt.Errorf("preset %s already exists, choose another name", preset.Name)
+		}
+
+		workspaceCfg.Presets[index] = preset
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		color.Green("✅ Preset %s updated %s", preset.Name, formatParams(preset.Params))
+		return nil
+	},
+}
+
+var presetRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "remove a preset",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the preset name as argument")
+		}
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		index := slices.IndexFunc(workspaceCfg.Presets, func(item config.Preset) bool {
+			return item.Name == args[0]
+		})
+		if index < 0 {
+			color.Yellow("⚠️ Preset %s not found", args[0])
+			return nil
+		}
+		workspaceCfg.Presets = slices.Delete(workspaceCfg.Presets, index, index+1)
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		color.Green("✅ Preset %s removed", args[0])
+		return nil
+	},
+}
+
+var presetExportCmd = &cobra.Command{
+	Use:   "export [name]...",
+	Short: "export presets as YAML",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		_, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		export := presetExport{Presets: make([]config.Preset, 0)}
+		for _, preset := range workspaceCfg.Presets {
+			if len(args) == 0 || slices.Contains(args, preset.Name) {
+				export.Presets = append(export.Presets, preset)
+			}
+		}
+		data, err := yaml.Marshal(&export)
+		if err != nil {
+			return fmt.Errorf("failed to encode presets: %w", err)
+		}
+		if output == "" {
+			fmt.Print(string(data))
+			return nil
+		}
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		color.Green("✅ %d presets exported to %s", len(export.Presets), output)
+		return nil
+	},
+}
+
+var presetRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "trigger the job of a preset with its expanded parameters",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the preset name as argument")
+		}
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		preset, ok := findPreset(workspaceCfg, args[0])
+		if !ok {
+			return fmt.Errorf("preset %s not found, see 'jenkins-cli preset list'", args[0])
+		}
+		params, err := util.ExpandTemplates(preset.Params)
+		if err != nil {
+			return err
+		}
+		color.Cyan("📌 %s → job: %s %s", preset.Name, preset.Job, formatParams(params))
+
+		params, err = selectJobParams(account, &workspaceCfg, preset.View, preset.Job, params)
+		if err != nil {
+			return err
+		}
+		buildNumber, err := triggerBuild(account, &workspaceCfg, preset.View, preset.Job, params)
+		if err != nil {
+			return err
+		}
+		return notifyWhenFinished(cmd, account, preset.Job, buildNumber)
+	},
+}
+
+func findPreset(cfg config.Workspace, name string) (config.Preset, bool) {
+	for _, preset := range cfg.Presets {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+	return config.Preset{}, false
+}
+
+// editPresetInEditor opens preset as YAML in $EDITOR, which may carry arguments such
+// as "code --wait".
+func editPresetInEditor(preset config.Preset) (config.Preset, error) {
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	data, err := yaml.Marshal(&preset)
+	if err != nil {
+		return preset, err
+	}
+	file, err := os.CreateTemp("", "jenkins-cli-preset-*.yaml")
+	if err != nil {
+		return preset, err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return preset, err
+	}
+	file.Close()
+
+	editorCmd := exec.Command(editor[0], append(editor[1:], file.Name())...)
+	editorCmd.Stdin, editorCmd.Stdout, editorCmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return preset, fmt.Errorf("editor failed: %w", err)
+	}
+	data, err = os.ReadFile(file.Name())
+	if err != nil {
+		return preset, err
+	}
+	var edited config.Preset
+	if err := yaml.Unmarshal(data, &edited); err != nil {
+		return preset, fmt.Errorf("invalid preset: %w", err)
+	}
+	return edited, nil
+}
+
+func init() {
+	rootCmd.AddCommand(presetCmd)
+	presetCmd.AddCommand(presetListCmd, presetCreateCmd, presetEditCmd, presetRemoveCmd, presetExportCmd, presetRunCmd)
+	presetCmd.PersistentFlags().String("account", "", "account name")
+	for _, command := range []*cobra.Command{presetCreateCmd, presetEditCmd} {
+		command.Flags().String("view", "", "view name")
+		command.Flags().String("job", "", "job name")
+		command.Flags().StringArray("param", nil, "parameter as key=value, templates like {{git.branch}} allowed (repeatable)")
+	}
+	presetCreateCmd.Flags().String("from-build", "", "copy the parameters of this build, a number or a reference such as lastSuccessful")
+	presetEditCmd.Flags().StringArray("unset", nil, "remove a parameter (repeatable)")
+	presetExportCmd.Flags().StringP("output", "o", "", "write to file instead of stdout")
+	addNotifyFlag(presetRunCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// setupTestEditor points $EDITOR at a script taking a flag before the file, like
+// "code --wait", which replaces the edited preset with content.
+func setupTestEditor(t *testing.T, content string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("editor script needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "preset.yaml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(dir, "editor")
+	body := "#!/bin/sh\n[ \"$1\" = --wait ] || exit 1\ncp \"" + filepath.Join(dir, "preset.yaml") + "\" \"$2\"\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", script+" --wait")
+}
+
+func TestPresetEditInEditor(t *testing.T) {
+	setupTest(t)
+	runCommand(t, "sync", "default")
+	runCommand(t, "preset", "create", "one", "--job", "svc-api", "--param", "pro=dev", "--account", "default")
+	runCommand(t, "preset", "create", "two", "--job", "svc-api", "--param", "pro=prod", "--account", "default")
+
+	setupTestEditor(t, "name: two\njob: svc-api\n")
+	if output, err := executeCommand(t, "preset", "edit", "one", "--account", "default"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("rename onto an existing preset = %v\n%s", err, output)
+	}
+
+	setupTestEditor(t, "name: three\njob: svc-web\nparams:\n  pro: dev\n")
+	runCommand(t, "preset", "edit", "one", "--account", "default")
+	names := make([]string, 0)
+	for _, preset := range loadTestWorkspace(t).Presets {
+		names = append(names, preset.Name+"/"+preset.Job)
+	}
+	if strings.Join(names, ",") != "three/svc-web,two/svc-api" {
+		t.Errorf("presets after edit = %v", names)
+	}
+}
+
+func TestPresetCreateFromBuildReference(t *testing.T) {
+	server, _ := setupTest(t)
+	failed := server.Build("svc-api", 2)
+	server.Update(func() { failed.Params = map[string]string{"pro": "prod", "tag": "release"} })
+	runCommand(t, "sync", "default")
+	runCommand(t, "preset", "create", "hotfix", "--job", "svc-api", "--from-build", "lastFailed", "--account", "default")
+
+	preset, ok := findPreset(loadTestWorkspace(t), "hotfix")
+	if !ok || preset.Source != "svc-api#2" || preset.Params["pro"] != "prod" || preset.Params["tag"] != "release" {
+		t.Errorf("preset = %+v", preset)
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/fatih/color"
 	"github.com/lemonsoul/jenkins-cli/api"
 	"github.com/lemonsoul/jenkins-cli/util"
@@ -11,17 +13,15 @@ var queueCmd = &cobra.Command{
 	Use:   "queue",
 	Short: "queue",
 	Long:  `task queue`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		account, err := util.PickAccount("")
 		if err != nil {
-			color.Red("❌ Error loading account configuration: %v", err)
-			return
+			return fmt.Errorf("failed to load account configuration: %w", err)
 		}
 
 		queueArray, err := api.GetQueue(account)
 		if err != nil {
-			color.Red("❌ Error getting queue information: %v", err)
-			return
+			return fmt.Errorf("failed to get queue information: %w", err)
 		}
 
 		queueJobArray := make([]util.QueueSelectItem, 0)
@@ -46,8 +46,7 @@ var queueCmd = &cobra.Command{
 
 		computerArray, err := api.GetComputer(account)
 		if err != nil {
-			color.Red("❌ Error getting computer information: %v", err)
-			return
+			return fmt.Errorf("failed to get computer information: %w", err)
 		}
 
 		if len(computerArray) == 0 {
@@ -57,6 +56,7 @@ var queueCmd = &cobra.Command{
 				color.White("🚀  JobName: %s, BuildNumber: %d", item.JobName, item.BuildNumber)
 			}
 		}
+		return nil
 	},
 }
 
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+var recentDepthCmd = &cobra.Command{
+	Use:   "recent-depth",
+	Short: "recent-depth [N]",
+	Long:  `show or set how many recent views, jobs, choices and branches are kept at the top of the selections`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		if len(args) == 0 {
+			color.Cyan("↺ Recent depth: %d", util.GetRecentDepth(workspaceCfg))
+			return nil
+		}
+		depth, err := strconv.Atoi(args[0])
+		if err != nil || depth <= 0 {
+			return usageError("invalid recent depth %s, provide a positive number", args[0])
+		}
+		workspaceCfg.RecentDepth = depth
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		color.Green("✅ Recent depth set to %d", depth)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(recentDepthCmd)
+	recentDepthCmd.Flags().String("account", "", "account name")
+}
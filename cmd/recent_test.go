@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRecentDepth(t *testing.T) {
+	setupTest(t)
+	runCommand(t, "sync", "default")
+	if output := runCommand(t, "recent-depth", "--account", "default"); !strings.Contains(output, "Recent depth: 3") {
+		t.Errorf("default recent depth output:\n%s", output)
+	}
+	runCommand(t, "recent-depth", "5", "--account", "default")
+	if depth := loadTestWorkspace(t).RecentDepth; depth != 5 {
+		t.Errorf("recent depth = %d, want 5", depth)
+	}
+	if _, err := executeCommand(t, "recent-depth", "0", "--account", "default"); exitCode(err) != exitUsage {
+		t.Errorf("recent-depth 0 exit code = %d, want %d", exitCode(err), exitUsage)
+	}
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"gopkg.in/yaml.v3"
+)
+
+const refreshAdoptTimeout = 5 * time.Second
+
+// workspaceRefresh syncs stale workspace data in the background while the
+// interactive prompts keep working on the cached copy.
+type workspaceRefresh struct {
+	mu        sync.Mutex
+	done      chan struct{}
+	finished  bool
+	abandoned bool
+	result    config.Workspace
+	err       error
+}
+
+// startWorkspaceRefresh starts a background sync when any part of cfg is older than
+// the account's TTL. It returns nil when the workspace is fresh.
+func startWorkspaceRefresh(account config.JenkinsConfig, cfg config.Workspace) *workspaceRefresh {
+	ttl := util.GetWorkspaceTTL(account)
+	if !workspaceIsStale(cfg, ttl) {
+		return nil
+	}
+	snapshot, err := cloneWorkspace(cfg)
+	if err != nil {
+		return nil
+	}
+	color.Yellow("♻️ Workspace data is older than %s, refreshing in background...", ttl)
+	refresh := &workspaceRefresh{done: make(chan struct{})}
+	go func() {
+		defer close(refresh.done)
+		result, err := syncWorkspace(account, snapshot, syncOptions{StaleAfter: ttl, Quiet: true})
+		refresh.mu.Lock()
+		defer refresh.mu.Unlock()
+		if err == nil && !refresh.abandoned {
+			err = saveWorkspaceFile(account.Name, result)
+		}
+		refresh.result, refresh.err = result, err
+		refresh.finished = true
+	}()
+	return refresh
+}
+
+// adopt waits up to timeout for the refresh, a zero timeout only takes a refresh that
+// already finished. A finished refresh replaces cfg; an unfinished one is abandoned so
+// it never overwrites changes written after this call.
+func (r *workspaceRefresh) adopt(cfg config.Workspace, timeout time.Duration) config.Workspace {
+	if r == nil {
+		return cfg
+	}
+	if timeout > 0 {
+		select {
+		case <-r.done:
+		case <-time.After(timeout):
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.finished {
+		r.abandoned = true
+		color.Yellow("⚠️ Background refresh did not finish in time, it will be retried next run.")
+		return cfg
+	}
+	if r.err != nil {
+		color.Yellow("⚠️ Background refresh failed: %v", r.err)
+		return cfg
+	}
+	color.Green("✅ Workspace refreshed in background.")
+	return r.result
+}
+
+func workspaceIsStale(cfg config.Workspace, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+	if isStale(cfg.SyncedAt, ttl) {
+		return true
+	}
+	for _, view := range util.AllViews(&cfg) {
+		if isStale(view.SyncedAt, ttl) {
+			return true
+		}
+		for _, job := range view.Job {
+			if isStale(job.SyncedAt, ttl) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func cloneWorkspace(cfg config.Workspace) (config.Workspace, error) {
+	data, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return config.Workspace{}, err
+	}
+	var clone config.Workspace
+	if err := yaml.Unmarshal(data, &clone); err != nil {
+		return config.Workspace{}, err
+	}
+	return clone, nil
+}
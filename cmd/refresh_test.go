@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+func TestAdoptWithoutTimeoutDoesNotWait(t *testing.T) {
+	cached := config.Workspace{Views: []config.View{{Name: "cached"}}}
+	pending := &workspaceRefresh{done: make(chan struct{})}
+	start := time.Now()
+	if got := pending.adopt(cached, 0); len(got.Views) != 1 || got.Views[0].Name != "cached" {
+		t.Errorf("adopt of a pending refresh = %+v", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("adopt waited %s", elapsed)
+	}
+	if !pending.abandoned {
+		t.Error("pending refresh was not abandoned")
+	}
+
+	finished := &workspaceRefresh{done: make(chan struct{}), finished: true,
+		result: config.Workspace{Views: []config.View{{Name: "fresh"}}}}
+	close(finished.done)
+	if got := finished.adopt(cached, 0); len(got.Views) != 1 || got.Views[0].Name != "fresh" {
+		t.Errorf("adopt of a finished refresh = %+v", got)
+	}
+}
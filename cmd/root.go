@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/briandowns/spinner"
 	"github.com/fatih/color"
@@ -17,123 +24,289 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "jenkins-cli",
 	Short: "jcli",
-	Long:  `jenkins-cli is a command line tool for managing Jenkins jobs and builds.`,
-	Run: func(cmd *cobra.Command, args []string) {
+	Long:  "jenkins-cli is a command line tool for managing Jenkins jobs and builds.\n\n" + exitCodeHelp,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		color.Cyan("🎈 Welcome to Jenkins CLI!")
 		accountName, _ := cmd.Flags().GetString("account")
 		viewName, _ := cmd.Flags().GetString("view")
 		jobName, _ := cmd.Flags().GetString("job")
+		byView, _ := cmd.Flags().GetBool("by-view")
+		branch, _ := cmd.Flags().GetString("branch")
 
-		var account config.JenkinsConfig
-		var err error
-		if accountName != "" {
-			account, err = util.GetAccountByName(accountName)
-		} else {
-			account, err = util.PickAccount("")
-		}
+		account, err := resolveAccount(accountName)
 		if err != nil {
-			color.Red("❌ Error loading account configuration: %v", err)
-			return
+			return fmt.Errorf("failed to load account configuration: %w", err)
 		}
 
 		workspaceCfg, err := util.GetWorkspaceFile(account.Name)
 		if err != nil {
-			color.Red("❌ Error loading workspace configuration: %v", err)
-			return
+			return fmt.Errorf("failed to load workspace configuration: %w", err)
 		}
 		if normalizeWorkspaceRecent(&workspaceCfg, account.Name) {
 			color.Yellow("⚠️ Workspace recent updated.")
 		}
+		refresh := startWorkspaceRefresh(account, workspaceCfg)
 
+		// The refresh only gets time to finish while a prompt is showing, a job given
+		// with --job goes straight to its parameters.
+		adoptTimeout := time.Duration(0)
+		var alias *config.Alias
 		if jobName == "" {
-			if viewName == "" {
-				viewName = selectView(workspaceCfg)
+			adoptTimeout = refreshAdoptTimeout
+			if viewName == "" && !byView {
+				viewName, jobName, alias = selectGlobalJob(workspaceCfg)
+			} else {
 				if viewName == "" {
-					color.Red("🚨 No view selected, please try again.")
-					return
+					viewName = selectView(workspaceCfg)
+					if viewName == "" {
+						return errors.New("no view selected")
+					}
+				}
+				jobName, alias = selectJobOrAlias(workspaceCfg, viewName)
+			}
+			if alias != nil {
+				color.Cyan("⚡ Running alias %s", alias.Name)
+				if alias.View != "" {
+					viewName = alias.View
 				}
+				jobName = alias.Job
 			}
-			jobName = selectJob(workspaceCfg, viewName)
 			if jobName == "" {
-				color.Red("🚨 No job selected, please try again.")
-				return
+				return errors.New("no job selected")
 			}
 		}
 
-		choices, branches, err := api.GetJobParams(account, jobName)
+		workspaceCfg = refresh.adopt(workspaceCfg, adoptTimeout)
+
+		fixed := make(map[string]string)
+		if alias != nil {
+			maps.Copy(fixed, alias.Params)
+		}
+		if branch != "" {
+			if err := util.ValidateBranchName(branch); err != nil {
+				return fmt.Errorf("invalid branch: %w", err)
+			}
+			fixed[config.PARAM_BRANCH] = branch
+		}
+		params, err := selectJobParams(account, &workspaceCfg, viewName, jobName, fixed)
 		if err != nil {
-			color.Red("❌ Error getting job parameters: %v", err)
-			return
+			return err
 		}
-
-		if updateWorkspaceParams(&workspaceCfg, account.Name, viewName, jobName, choices, branches) {
-			color.Yellow("⚠️ Workspace params updated.")
+		buildNumber, err := triggerBuild(account, &workspaceCfg, viewName, jobName, params)
+		if err != nil {
+			return err
 		}
+		return notifyWhenFinished(cmd, account, jobName, buildNumber)
+	},
+}
 
-		choicesSelect := util.StrUISelectWithRecent("Select Choices", choices, getJobRecentChoices(workspaceCfg, viewName, jobName, choices))
-		branchSelect := util.StrUISelectWithRecent("Select Branch", branches, getJobRecentBranches(workspaceCfg, viewName, jobName, branches))
+func resolveAccount(accountName string) (config.JenkinsConfig, error) {
+	if accountName != "" {
+		return util.GetAccountByName(accountName)
+	}
+	return util.PickAccount("")
+}
 
-		if jobName == "" || branchSelect == "" {
-			color.Red("🚨 Selection incomplete, please try again.")
-			return
+// resolveBuildArg turns the build argument of a command, a build number or a symbolic
+// reference such as lastFailed, into a build number.
+func resolveBuildArg(account config.JenkinsConfig, jobName, ref string) (string, error) {
+	if _, err := strconv.Atoi(ref); err != nil {
+		if _, ok := api.BuildPermalink(ref); !ok {
+			return "", usageError("invalid build %q, use a build number or one of %s", ref, strings.Join(api.BuildRefs(), ", "))
 		}
+	}
+	number, err := api.ResolveBuildNumber(account, jobName, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve build %s of %s: %w", ref, jobName, err)
+	}
+	return number, nil
+}
 
-		queueId, err := api.BuildWithParameters(account, jobName, choicesSelect, branchSelect)
-		if err != nil {
-			color.Red("❌ Error starting build: %v", err)
-			return
+// loadAccountWorkspace resolves the account from the --account flag and loads its
+// workspace.
+func loadAccountWorkspace(cmd *cobra.Command) (config.JenkinsConfig, config.Workspace, error) {
+	accountName, _ := cmd.Flags().GetString("account")
+	account, err := resolveAccount(accountName)
+	if err != nil {
+		return config.JenkinsConfig{}, config.Workspace{}, fmt.Errorf("failed to load account configuration: %w", err)
+	}
+	workspaceCfg, err := util.GetWorkspaceFile(account.Name)
+	if err != nil {
+		return config.JenkinsConfig{}, config.Workspace{}, fmt.Errorf("failed to load workspace configuration: %w", err)
+	}
+	return account, workspaceCfg, nil
+}
+
+// selectJobParams prompts for the choice and branch parameters of jobName that are
+// not already present in fixed. It fails when the selection is incomplete.
+func selectJobParams(account config.JenkinsConfig, workspaceCfg *config.Workspace, viewName, jobName string, fixed map[string]string) (map[string]string, error) {
+	params := make(map[string]string, len(fixed)+2)
+	for key, value := range fixed {
+		params[key] = value
+	}
+	_, hasChoice := params[config.PARAM_CHOICE]
+	_, hasBranch := params[config.PARAM_BRANCH]
+	if hasChoice && hasBranch {
+		return params, nil
+	}
+
+	choices, branches, err := api.GetJobParams(account, jobName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job parameters: %w", err)
+	}
+
+	if updateWorkspaceParams(workspaceCfg, account.Name, viewName, jobName, choices, branches) {
+		color.Yellow("⚠️ Workspace params updated.")
+	}
+
+	if !hasChoice {
+		params[config.PARAM_CHOICE] = util.StrUISelectWithRecent("Select Choices", choices, getJobRecentChoices(*workspaceCfg, viewName, jobName, choices))
+	}
+	if !hasBranch {
+		params[config.PARAM_BRANCH] = selectBranch(account, workspaceCfg, viewName, jobName, choices, branches)
+	}
+
+	if jobName == "" || params[config.PARAM_BRANCH] == "" {
+		return nil, errors.New("selection incomplete")
+	}
+	return params, nil
+}
+
+// triggerBuild starts jobName, records the selection as recent and reports the
+// build number and change sets once the build leaves the queue. It returns the
+// build number, empty while the build is still queued.
+func triggerBuild(account config.JenkinsConfig, workspaceCfg *config.Workspace, viewName, jobName string, params map[string]string) (string, error) {
+	choicesSelect := params[config.PARAM_CHOICE]
+	branchSelect := params[config.PARAM_BRANCH]
+
+	queueId, err := api.BuildWithParams(account, jobName, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to start build: %w", err)
+	}
+
+	if queueId != "" {
+		color.Cyan("🎉 Build " + jobName + choicesSelect + branchSelect + " success, queue id is " + queueId)
+		if updateWorkspaceRecent(workspaceCfg, account.Name, viewName, jobName, choicesSelect, branchSelect) {
+			color.Yellow("⚠️ Workspace recent updated.")
 		}
+	}
+	waitOperation(4)
+	buildNumber := getBuildNumber(account, queueId, 8)
+	if buildNumber == "" {
+		color.Yellow("♻️ job maybe waiting to run, please check it later")
+	} else {
+		color.Cyan("🍻 Build " + jobName + " " + choicesSelect + " " + branchSelect + " success, build number is " + buildNumber)
+	}
+	buildInfo, err := api.GetBuildStatus(account, jobName, buildNumber)
+	if err != nil {
+		color.Yellow("⚠️ Error getting build status: %v", err)
+		return buildNumber, nil
+	}
+	number, _ := strconv.Atoi(buildNumber)
+	printChanges([]buildChanges{{BuildNumber: number, ChangeSets: buildInfo.ChangeSets}})
+	return buildNumber, nil
+}
 
-		if queueId != "" {
-			color.Cyan("🎉 Build " + jobName + choicesSelect + branchSelect + " success, queue id is " + queueId)
-			if updateWorkspaceRecent(&workspaceCfg, account.Name, viewName, jobName, choicesSelect, branchSelect) {
-				color.Yellow("⚠️ Workspace recent updated.")
-			}
+const viewBackOption = "‹ Back"
+const viewGroupSuffix = " ▸"
+
+// selectView walks down the view tree one level at a time. Views holding nested
+// views open the next level, the label shows the path taken so far.
+func selectView(cfg config.Workspace) string {
+	depth := util.GetRecentDepth(cfg)
+	recentViews := util.FilterRecent(cfg.RecentViews, util.BuildAllowSet(util.AllViewNames(&cfg)), depth)
+	parentName := ""
+	for {
+		children := cfg.Views
+		if parent := util.FindView(&cfg, parentName); parent != nil {
+			children = parent.Views
 		}
-		waitOperation(4)
-		buildNumber := getBuildNumber(account, queueId, 8)
-		if buildNumber == "" {
-			color.Yellow("♻️ job maybe waiting to run, please check it later")
-		} else {
-			color.Cyan("🍻 Build " + jobName + " " + choicesSelect + " " + branchSelect + " success, build number is " + buildNumber)
+		labels := make([]string, 0, len(children)+2)
+		byLabel := make(map[string]string, len(children)+1)
+		if parentName != "" {
+			labels = append(labels, viewBackOption)
+			if parent := util.FindView(&cfg, parentName); parent != nil && len(parent.Job) > 0 {
+				label := viewLabel(parentName)
+				labels = append(labels, label)
+				byLabel[label] = parentName
+			}
 		}
-		buildInfo, err := api.GetBuildStatus(account, jobName, buildNumber)
-		if err != nil {
-			color.Yellow("⚠️ Error getting build status: %v", err)
-			return
+		labelOf := make(map[string]string, len(children))
+		for _, view := range children {
+			label := viewLabel(view.Name)
+			if len(view.Views) > 0 {
+				label += viewGroupSuffix
+			}
+			labels = append(labels, label)
+			byLabel[label] = view.Name
+			labelOf[view.Name] = label
 		}
-		if len(buildInfo.ChangeSets) > 0 {
-			for index, item := range buildInfo.ChangeSets {
-				color.Cyan(strconv.Itoa(index+1) + "、" + item.Comment + " by " + item.AuthorFullName)
+		recent := make([]string, 0)
+		for _, viewName := range recentViews {
+			if label, ok := labelOf[viewName]; ok {
+				recent = append(recent, label)
 			}
-		} else {
-			color.Yellow("⚠️ No change sets")
 		}
-	},
-}
 
-func selectView(cfg config.Workspace) string {
-	viewNames := make([]string, 0)
-	for _, item := range cfg.Views {
-		viewNames = append(viewNames, item.Name)
+		title := "Select View"
+		if parentName != "" {
+			title += " [" + strings.ReplaceAll(parentName, api.ViewSeparator, " › ") + "]"
+		}
+		selected := util.StrUISelectWithRecent(title, labels, recent)
+		switch {
+		case selected == "":
+			return ""
+		case selected == viewBackOption:
+			parentName = api.ViewParentName(parentName)
+			continue
+		}
+		viewName := byLabel[selected]
+		if view := util.FindView(&cfg, viewName); view != nil && len(view.Views) > 0 && viewName != parentName {
+			parentName = viewName
+			continue
+		}
+		return viewName
 	}
-	recent := util.FilterRecent(cfg.RecentViews, util.BuildAllowSet(viewNames), 3)
-	return util.StrUISelectWithRecent("Select View", viewNames, recent)
 }
 
 func selectJob(cfg config.Workspace, viewResult string) string {
+	jobNames, favorites, recent := viewJobChoices(cfg, viewResult)
+	return util.StrUISelectWithFavorites("Select Job", jobNames, favorites, recent)
+}
+
+// selectJobOrAlias is selectJob listing the aliases of the jobs of the view first. It
+// returns the selected job, or the selected alias.
+func selectJobOrAlias(cfg config.Workspace, viewResult string) (string, *config.Alias) {
+	jobNames, favorites, recent := viewJobChoices(cfg, viewResult)
+	aliases := make([]config.Alias, 0)
+	labels := make([]string, 0)
+	for _, alias := range cfg.Aliases {
+		if slices.Contains(jobNames, alias.Job) {
+			aliases = append(aliases, alias)
+			labels = append(labels, aliasLabel(alias))
+		}
+	}
+	jobName, aliasIndex := util.StrUISelectWithAliases("Select Job", labels, jobNames, favorites, recent)
+	if aliasIndex >= 0 {
+		return "", &aliases[aliasIndex]
+	}
+	return jobName, nil
+}
+
+// viewJobChoices returns the jobs of a view with the favorites and recent jobs among
+// them.
+func viewJobChoices(cfg config.Workspace, viewResult string) ([]string, []string, []string) {
+	depth := util.GetRecentDepth(cfg)
 	jobNames := make([]string, 0)
 	recent := make([]string, 0)
-	for _, item := range cfg.Views {
-		if item.Name == viewResult {
-			for _, job := range item.Job {
-				jobNames = append(jobNames, job.Name)
-			}
-			recent = util.FilterRecent(item.RecentJobs, util.BuildAllowSet(jobNames), 3)
-			break
+	if view := util.FindView(&cfg, viewResult); view != nil {
+		for _, job := range view.Job {
+			jobNames = append(jobNames, job.Name)
 		}
+		recent = util.FilterRecent(view.RecentJobs, util.BuildAllowSet(jobNames), depth)
 	}
-	return util.StrUISelectWithRecent("Select Job", jobNames, recent)
+	favorites := util.FilterRecent(cfg.Favorites, util.BuildAllowSet(jobNames), 0)
+	return jobNames, favorites, recent
 }
 
 func updateWorkspaceParams(workspaceCfg *config.Workspace, accountName, viewName, jobName string, choices, branches []string) bool {
@@ -141,29 +314,33 @@ func updateWorkspaceParams(workspaceCfg *config.Workspace, accountName, viewName
 		return false
 	}
 	updated := false
-	for viewIndex := range workspaceCfg.Views {
-		if viewName != "" && workspaceCfg.Views[viewIndex].Name != viewName {
+	touched := false
+	for _, view := range util.AllViews(workspaceCfg) {
+		if viewName != "" && view.Name != viewName {
 			continue
 		}
-		for jobIndex := range workspaceCfg.Views[viewIndex].Job {
-			job := workspaceCfg.Views[viewIndex].Job[jobIndex]
+		for jobIndex := range view.Job {
+			job := view.Job[jobIndex]
 			if job.Name != jobName {
 				continue
 			}
-			current := workspaceCfg.Views[viewIndex].Job[jobIndex].JobParam
+			current := view.Job[jobIndex].JobParam
+			view.Job[jobIndex].SyncedAt = time.Now()
+			touched = true
 			if slicesEqual(current.Choices, choices) && slicesEqual(current.Branch, branches) {
 				continue
 			}
-			workspaceCfg.Views[viewIndex].Job[jobIndex].JobParam.Choices = choices
-			workspaceCfg.Views[viewIndex].Job[jobIndex].JobParam.Branch = branches
+			view.Job[jobIndex].JobParam.Choices = choices
+			view.Job[jobIndex].JobParam.Branch = branches
 			updated = true
 		}
 	}
-	if !updated {
+	if !touched {
 		return false
 	}
+	// The refresh timestamp is persisted even when the params themselves are unchanged.
 	workspacePath := util.GetWorkspaceFilePathByName(accountName)
-	return writeWorkspaceFile(workspacePath, *workspaceCfg)
+	return writeWorkspaceFile(workspacePath, *workspaceCfg) && updated
 }
 
 func updateWorkspaceRecent(workspaceCfg *config.Workspace, accountName, viewName, jobName, choice, branch string) bool {
@@ -171,30 +348,31 @@ func updateWorkspaceRecent(workspaceCfg *config.Workspace, accountName, viewName
 		return false
 	}
 	updated := false
-	recentViews := util.UpdateRecent(workspaceCfg.RecentViews, viewName, 3)
+	depth := util.GetRecentDepth(*workspaceCfg)
+	recentViews := util.UpdateRecent(workspaceCfg.RecentViews, viewName, depth)
 	if !slicesEqual(workspaceCfg.RecentViews, recentViews) {
 		workspaceCfg.RecentViews = recentViews
 		updated = true
 	}
-	for viewIndex := range workspaceCfg.Views {
-		if viewName != "" && workspaceCfg.Views[viewIndex].Name != viewName {
+	for _, view := range util.AllViews(workspaceCfg) {
+		if viewName != "" && view.Name != viewName {
 			continue
 		}
-		recentJobs := util.UpdateRecent(workspaceCfg.Views[viewIndex].RecentJobs, jobName, 3)
-		if !slicesEqual(workspaceCfg.Views[viewIndex].RecentJobs, recentJobs) {
-			workspaceCfg.Views[viewIndex].RecentJobs = recentJobs
+		recentJobs := util.UpdateRecent(view.RecentJobs, jobName, depth)
+		if !slicesEqual(view.RecentJobs, recentJobs) {
+			view.RecentJobs = recentJobs
 			updated = true
 		}
-		for jobIndex := range workspaceCfg.Views[viewIndex].Job {
-			job := workspaceCfg.Views[viewIndex].Job[jobIndex]
+		for jobIndex := range view.Job {
+			job := view.Job[jobIndex]
 			if job.Name != jobName {
 				continue
 			}
-			recentChoices := util.UpdateRecent(job.RecentChoices, choice, 3)
-			recentBranches := util.UpdateRecent(job.RecentBranches, branch, 3)
+			recentChoices := util.UpdateRecent(job.RecentChoices, choice, depth)
+			recentBranches := util.UpdateRecent(job.RecentBranches, branch, depth)
 			if !slicesEqual(job.RecentChoices, recentChoices) || !slicesEqual(job.RecentBranches, recentBranches) {
-				workspaceCfg.Views[viewIndex].Job[jobIndex].RecentChoices = recentChoices
-				workspaceCfg.Views[viewIndex].Job[jobIndex].RecentBranches = recentBranches
+				view.Job[jobIndex].RecentChoices = recentChoices
+				view.Job[jobIndex].RecentBranches = recentBranches
 				updated = true
 			}
 		}
@@ -207,14 +385,15 @@ func updateWorkspaceRecent(workspaceCfg *config.Workspace, accountName, viewName
 }
 
 func getJobRecentChoices(cfg config.Workspace, viewName, jobName string, allow []string) []string {
+	depth := util.GetRecentDepth(cfg)
 	allowSet := util.BuildAllowSet(allow)
-	for _, view := range cfg.Views {
+	for _, view := range util.AllViews(&cfg) {
 		if viewName != "" && view.Name != viewName {
 			continue
 		}
 		for _, job := range view.Job {
 			if job.Name == jobName {
-				return util.FilterRecent(job.RecentChoices, allowSet, 3)
+				return util.FilterRecent(job.RecentChoices, allowSet, depth)
 			}
 		}
 	}
@@ -222,14 +401,15 @@ func getJobRecentChoices(cfg config.Workspace, viewName, jobName string, allow [
 }
 
 func getJobRecentBranches(cfg config.Workspace, viewName, jobName string, allow []string) []string {
+	depth := util.GetRecentDepth(cfg)
 	allowSet := util.BuildAllowSet(allow)
-	for _, view := range cfg.Views {
+	for _, view := range util.AllViews(&cfg) {
 		if viewName != "" && view.Name != viewName {
 			continue
 		}
 		for _, job := range view.Job {
 			if job.Name == jobName {
-				return util.FilterRecent(job.RecentBranches, allowSet, 3)
+				return util.FilterRecent(job.RecentBranches, allowSet, depth)
 			}
 		}
 	}
@@ -267,41 +447,28 @@ func normalizeWorkspaceRecent(workspaceCfg *config.Workspace, accountName string
 	}
 	viewNames := make([]string, 0)
 	updated := false
-	for _, view := range workspaceCfg.Views {
+	depth := util.GetRecentDepth(*workspaceCfg)
+	for _, view := range util.AllViews(workspaceCfg) {
 		viewNames = append(viewNames, view.Name)
 		jobNames := make([]string, 0, len(view.Job))
-		for _, job := range view.Job {
+		for jobIndex := range view.Job {
+			job := &view.Job[jobIndex]
 			jobNames = append(jobNames, job.Name)
-			recentChoices := util.FilterRecent(job.RecentChoices, util.BuildAllowSet(job.JobParam.Choices), 3)
-			recentBranches := util.FilterRecent(job.RecentBranches, util.BuildAllowSet(job.JobParam.Branch), 3)
+			recentChoices := util.FilterRecent(job.RecentChoices, util.BuildAllowSet(job.JobParam.Choices), depth)
+			recentBranches := util.FilterRecent(job.RecentBranches, util.BuildAllowSet(job.JobParam.Branch), depth)
 			if !slicesEqual(job.RecentChoices, recentChoices) || !slicesEqual(job.RecentBranches, recentBranches) {
-				for viewIndex := range workspaceCfg.Views {
-					if workspaceCfg.Views[viewIndex].Name != view.Name {
-						continue
-					}
-					for jobIndex := range workspaceCfg.Views[viewIndex].Job {
-						if workspaceCfg.Views[viewIndex].Job[jobIndex].Name == job.Name {
-							workspaceCfg.Views[viewIndex].Job[jobIndex].RecentChoices = recentChoices
-							workspaceCfg.Views[viewIndex].Job[jobIndex].RecentBranches = recentBranches
-							updated = true
-							break
-						}
-					}
-				}
+				job.RecentChoices = recentChoices
+				job.RecentBranches = recentBranches
+				updated = true
 			}
 		}
-		recentJobs := util.FilterRecent(view.RecentJobs, util.BuildAllowSet(jobNames), 3)
+		recentJobs := util.FilterRecent(view.RecentJobs, util.BuildAllowSet(jobNames), depth)
 		if !slicesEqual(view.RecentJobs, recentJobs) {
-			for viewIndex := range workspaceCfg.Views {
-				if workspaceCfg.Views[viewIndex].Name == view.Name {
-					workspaceCfg.Views[viewIndex].RecentJobs = recentJobs
-					updated = true
-					break
-				}
-			}
+			view.RecentJobs = recentJobs
+			updated = true
 		}
 	}
-	recentViews := util.FilterRecent(workspaceCfg.RecentViews, util.BuildAllowSet(viewNames), 3)
+	recentViews := util.FilterRecent(workspaceCfg.RecentViews, util.BuildAllowSet(viewNames), depth)
 	if slicesEqual(workspaceCfg.RecentViews, recentViews) && !updated {
 		return false
 	}
@@ -310,11 +477,33 @@ func normalizeWorkspaceRecent(workspaceCfg *config.Workspace, accountName string
 	return writeWorkspaceFile(workspacePath, *workspaceCfg)
 }
 
+// Execute runs the command line and exits with the code documented in exitCodeHelp
+// when the command fails.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		color.Red(err.Error())
-		os.Exit(1)
+	err := rootCmd.Execute()
+	if closeErr := closeTraceFile(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		return
+	}
+	code := exitCode(err)
+	if code == exitUsage {
+		color.White(sentence(err.Error()))
+	} else {
+		color.Red("❌ %s", sentence(err.Error()))
+	}
+	os.Exit(code)
+}
+
+// sentence capitalizes an error message, which starts in lower case like Go error
+// strings do, to print it on its own.
+func sentence(message string) string {
+	if message == "" {
+		return message
 	}
+	r, size := utf8.DecodeRuneInString(message)
+	return string(unicode.ToUpper(r)) + message[size:]
 }
 
 func waitOperation(second time.Duration) {
@@ -348,4 +537,13 @@ func init() {
 	rootCmd.Flags().String("account", "", "account name")
 	rootCmd.Flags().String("view", "", "view name")
 	rootCmd.Flags().String("job", "", "job name")
+	rootCmd.Flags().Bool("by-view", false, "select a view first instead of searching all jobs")
+	rootCmd.Flags().String("branch", "", "branch to build, may be one Jenkins has not listed yet")
+	addNotifyFlag(rootCmd)
+	addTransportFlags(rootCmd)
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &UsageError{Message: err.Error()}
+	})
 }
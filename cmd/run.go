@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "run <alias>",
+	Long:  `trigger the job saved under an alias, prompting only for parameters the alias does not fix`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the alias name as argument")
+		}
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		alias, ok := findAlias(workspaceCfg, args[0])
+		if !ok {
+			return fmt.Errorf("alias %s not found, see 'jenkins-cli alias list'", args[0])
+		}
+
+		params, err := selectJobParams(account, &workspaceCfg, alias.View, alias.Job, alias.Params)
+		if err != nil {
+			return err
+		}
+		buildNumber, err := triggerBuild(account, &workspaceCfg, alias.View, alias.Job, params)
+		if err != nil {
+			return err
+		}
+		return notifyWhenFinished(cmd, account, alias.Job, buildNumber)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().String("account", "", "account name")
+	addNotifyFlag(runCmd)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -15,24 +16,25 @@ import (
 
 var stagesCmd = &cobra.Command{
 	Use:   "stages",
-	Short: "stages <jobName> <buildNumber>",
+	Short: "stages <jobName> <build>",
 	Long:  `task stages`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 2 {
-			color.White("Please provide the job name and build number as arguments.")
-			return
+			return usageError("provide the job name and build number as arguments")
 		}
 		account, err := util.PickAccount("")
 		if err != nil {
-			color.Red("❌ Error loading account configuration: %v", err)
-			return
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		buildNumber, err := resolveBuildArg(account, args[0], args[1])
+		if err != nil {
+			return err
 		}
 		//color.White("Fetching stages for job:", args[0], "and build number:", args[1])
 		//pipelineInfo := api.GetPipelineConfig(args[0])
-		wFDescribe, err := api.GetWFDescribe(account, args[0], args[1])
+		wFDescribe, err := api.GetWFDescribe(account, args[0], buildNumber)
 		if err != nil {
-			color.Red("❌ Error getting workflow description: %v", err)
-			return
+			return fmt.Errorf("failed to get workflow description: %w", err)
 		}
 
 		color.Cyan("📦 Project Name:%s", args[0])
@@ -44,10 +46,10 @@ var stagesCmd = &cobra.Command{
 			//return
 		} else if strings.Compare(wFDescribe.Status, "FAILURE") == 0 {
 			color.Red("⏳ Status:%s", "❌ Build is failure")
-			return
+			return nil
 		} else if strings.Compare(wFDescribe.Status, "ABORTED") == 0 {
 			color.Yellow("⏳ Status:%s", "🛑 Build is aborted")
-			return
+			return nil
 		} else if strings.Compare(wFDescribe.Status, "IN_PROGRESS") == 0 {
 			color.Cyan("⏳ Status:%s", "🔄 Build is in progress")
 		}
@@ -64,7 +66,7 @@ var stagesCmd = &cobra.Command{
 		Loop:
 			for {
 				if !complate {
-					wFDescribe, _ = api.GetWFDescribe(account, args[0], args[1])
+					wFDescribe, _ = api.GetWFDescribe(account, args[0], buildNumber)
 				}
 				if complate || strings.Compare(wFDescribe.Status, "SUCCESS") == 0 {
 					complate = true
@@ -83,6 +85,7 @@ var stagesCmd = &cobra.Command{
 				}
 			}
 		}
+		return nil
 	},
 }
 
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+const statusProgressWidth = 10
+const statusMinInterval = time.Second
+
+type viewStatus struct {
+	Name string
+	Jobs []config.JobStatus
+	Err  error
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "status [--view V] [--watch] | status <jobName> <build>",
+	Long: `show a dashboard of the last builds, running builds and health of the jobs of each view,
+or the details of a single build given by number or as last, lastFailed, lastSuccessful...`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			if len(args) != 2 {
+				return usageError("provide the job name and build as arguments, or none for the dashboard")
+			}
+			if cmd.Flags().Changed("view") || cmd.Flags().Changed("watch") {
+				return usageError("--view and --watch only apply to the dashboard")
+			}
+			accountName, _ := cmd.Flags().GetString("account")
+			account, err := resolveAccount(accountName)
+			if err != nil {
+				return fmt.Errorf("failed to load account configuration: %w", err)
+			}
+			return showBuildStatus(account, args[0], args[1])
+		}
+		viewNames, _ := cmd.Flags().GetStringSlice("view")
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval := statusInterval(cmd)
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		if len(viewNames) == 0 {
+			for _, view := range util.AllViews(&workspaceCfg) {
+				if len(view.Job) > 0 {
+					viewNames = append(viewNames, view.Name)
+				}
+			}
+		}
+		if len(viewNames) == 0 {
+			color.Yellow("⚠️ No views in workspace, run 'jenkins-cli sync' first")
+			return nil
+		}
+
+		for {
+			statuses := make([]viewStatus, 0, len(viewNames))
+			for _, viewName := range viewNames {
+				jobs, err := api.GetViewStatus(account, viewName)
+				statuses = append(statuses, viewStatus{Name: viewName, Jobs: jobs, Err: err})
+			}
+			if watch {
+				fmt.Print("\033[H\033[2J")
+			}
+			printStatusDashboard(statuses)
+			if !watch {
+				return statusError(statuses)
+			}
+			color.HiBlack("updated %s, refreshing every %s, press Ctrl+C to stop", time.Now().Format("15:04:05"), interval)
+			time.Sleep(interval)
+		}
+	},
+}
+
+// statusInterval returns the refresh interval of the dashboard, at least
+// statusMinInterval so that a zero or negative --interval does not poll Jenkins in
+// a busy loop.
+func statusInterval(cmd *cobra.Command) time.Duration {
+	interval, _ := cmd.Flags().GetDuration("interval")
+	return max(interval, statusMinInterval)
+}
+
+func showBuildStatus(account config.JenkinsConfig, jobName, ref string) error {
+	buildNumber, err := resolveBuildArg(account, jobName, ref)
+	if err != nil {
+		return err
+	}
+	build, err := api.GetBuildStatus(account, jobName, buildNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get build status: %w", err)
+	}
+	printBuildStatus(jobName, build)
+	return nil
+}
+
+func printBuildStatus(jobName string, build config.BuildInfo) {
+	number, _ := strconv.Atoi(build.BuildNumber)
+	summary := config.BuildSummary{
+		JobName:           jobName,
+		Number:            number,
+		Result:            build.Result,
+		Building:          build.Building,
+		Duration:          int64(build.Duration),
+		EstimatedDuration: build.EstimatedDuration,
+		Timestamp:         build.Timestamp,
+		Causes:            build.Causes,
+	}
+	fmt.Printf("%s  %s\n", color.CyanString("🔎 %s #%d", jobName, number), util.ColorizeStatus(buildStatus(summary)))
+	if build.Description != "" {
+		printStatusField("Description", build.Description)
+	}
+	printStatusField("URL", build.Url)
+	started := "-"
+	if build.Timestamp > 0 {
+		started = time.UnixMilli(build.Timestamp).Format("2006-01-02 15:04:05") + " (" + formatAgo(build.Timestamp) + ")"
+	}
+	printStatusField("Started", started)
+	if build.Building {
+		printStatusField("Progress", formatBuildProgress(summary))
+	} else {
+		duration := formatBuildDuration(summary)
+		if build.EstimatedDuration > 0 {
+			duration += fmt.Sprintf(" (estimated %s)", (time.Duration(build.EstimatedDuration) * time.Millisecond).Round(time.Second))
+		}
+		printStatusField("Duration", duration)
+	}
+	builtOn := build.BuiltOn
+	if builtOn == "" {
+		builtOn = "built-in node"
+	}
+	printStatusField("Built on", builtOn)
+
+	causes := make([]string, 0, len(build.Causes))
+	for _, cause := range build.Causes {
+		causes = append(causes, cause.ShortDescription)
+	}
+	printStatusList("Causes", causes)
+	names := slices.Sorted(maps.Keys(build.Parameters))
+	parameters := make([]string, 0, len(names))
+	for _, name := range names {
+		parameters = append(parameters, name+"="+build.Parameters[name])
+	}
+	printStatusList("Parameters", parameters)
+	fmt.Println()
+	printChanges([]buildChanges{{BuildNumber: number, ChangeSets: build.ChangeSets}})
+}
+
+func printStatusField(label, value string) {
+	fmt.Printf("  %s  %s\n", color.HiBlackString("%-11s", label), value)
+}
+
+// printStatusList prints one value per line, aligned under the first.
+func printStatusList(label string, values []string) {
+	if len(values) == 0 {
+		printStatusField(label, "-")
+		return
+	}
+	for index, value := range values {
+		if index > 0 {
+			label = ""
+		}
+		printStatusField(label, value)
+	}
+}
+
+// statusError reports the views whose status could not be fetched, they are
+// already shown in the dashboard.
+func statusError(statuses []viewStatus) error {
+	failures := make([]error, 0)
+	for _, status := range statuses {
+		if status.Err != nil {
+			failures = append(failures, status.Err)
+		}
+	}
+	return partialError(failures, len(statuses))
+}
+
+func printStatusDashboard(statuses []viewStatus) {
+	for _, status := range statuses {
+		if status.Err != nil {
+			color.Red("❌ Error getting status of view %s: %v", status.Name, status.Err)
+			continue
+		}
+		color.Cyan("📋 %s (%d jobs)", status.Name, len(status.Jobs))
+		width := len("JOB")
+		for _, job := range status.Jobs {
+			width = max(width, len(job.Name))
+		}
+		color.HiBlack("  %-*s  %-14s  %-10s  %-34s  %-16s  %s", width, "JOB", "STATUS", "HEALTH", "LAST BUILD", "LAST SUCCESS", "LAST FAILURE")
+		for _, job := range status.Jobs {
+			jobStatus := util.JobColorStatus(job.Color)
+			fmt.Printf("  %s  %s%s  %-10s  %-34s  %-16s  %s\n",
+				color.CyanString("%-*s", width, job.Name),
+				util.ColorizeStatus(jobStatus), strings.Repeat(" ", max(12-len(jobStatus), 0)),
+				formatHealth(job.HealthScore),
+				formatLastBuild(job.LastBuild),
+				formatBuildRef(job.LastSuccessfulBuild),
+				formatBuildRef(job.LastFailedBuild))
+		}
+		fmt.Println()
+	}
+}
+
+// formatHealth shows the health score with the weather icon Jenkins uses for it.
+func formatHealth(score int) string {
+	if score < 0 {
+		return "-"
+	}
+	weather := "⛈"
+	switch {
+	case score > 80:
+		weather = "☀"
+	case score > 60:
+		weather = "⛅"
+	case score > 40:
+		weather = "☁"
+	case score > 20:
+		weather = "🌧"
+	}
+	return fmt.Sprintf("%s %3d%%", weather, score)
+}
+
+func formatLastBuild(build config.BuildSummary) string {
+	if build.Number == 0 {
+		return "-"
+	}
+	if !build.Building {
+		return fmt.Sprintf("#%d %s, %s", build.Number, formatAgo(build.Timestamp), formatBuildDuration(build))
+	}
+	return fmt.Sprintf("#%d %s", build.Number, formatBuildProgress(build))
+}
+
+// formatBuildProgress draws the elapsed time of a running build against its
+// estimated duration.
+func formatBuildProgress(build config.BuildSummary) string {
+	elapsed := time.Since(time.UnixMilli(build.Timestamp))
+	if build.EstimatedDuration <= 0 {
+		return "running " + elapsed.Round(time.Second).String()
+	}
+	percent := min(int(elapsed.Milliseconds()*100/build.EstimatedDuration), 99)
+	filled := percent * statusProgressWidth / 100
+	return fmt.Sprintf("[%s%s] %2d%% %s", strings.Repeat("#", filled), strings.Repeat("-", statusProgressWidth-filled),
+		percent, elapsed.Round(time.Second))
+}
+
+func formatBuildRef(build config.BuildSummary) string {
+	if build.Number == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("#%d %s", build.Number, formatAgo(build.Timestamp))
+}
+
+func formatAgo(timestamp int64) string {
+	if timestamp <= 0 {
+		return "-"
+	}
+	elapsed := time.Since(time.UnixMilli(timestamp))
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm ago", int(elapsed.Minutes()))
+	case elapsed < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(elapsed.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(elapsed.Hours()/24))
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().String("account", "", "account name")
+	statusCmd.Flags().StringSlice("view", nil, "only show the given views (repeatable)")
+	statusCmd.Flags().BoolP("watch", "w", false, "refresh the dashboard in place")
+	statusCmd.Flags().Duration("interval", 10*time.Second, "refresh interval for --watch, at least 1s")
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+)
+
+func TestStatusDashboard(t *testing.T) {
+	server, _ := setupTest(t)
+	server.AddBuild("svc-api", &jenkinstest.Build{Building: true, EstimatedDuration: 120000, Timestamp: time.Now().Add(-time.Minute).UnixMilli()})
+	runCommand(t, "sync", "default")
+
+	output := runCommand(t, "status", "--view", "team/backend", "--account", "default")
+	for _, expected := range []string{"team/backend (1 jobs)", "svc-api", "#3", "#1", "#2"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("status output misses %q:\n%s", expected, output)
+		}
+	}
+}
+
+func TestStatusIntervalClamp(t *testing.T) {
+	t.Cleanup(func() { resetFlags(statusCmd) })
+	cases := map[string]time.Duration{"0s": statusMinInterval, "-5s": statusMinInterval, "30s": 30 * time.Second}
+	for value, expected := range cases {
+		statusCmd.Flags().Set("interval", value)
+		if got := statusInterval(statusCmd); got != expected {
+			t.Errorf("statusInterval with --interval %s = %s, want %s", value, got, expected)
+		}
+	}
+}
+
+func TestFormatHealth(t *testing.T) {
+	cases := map[int]string{-1: "-", 100: "☀ 100%", 0: "⛈   0%"}
+	for score, expected := range cases {
+		if got := formatHealth(score); got != expected {
+			t.Errorf("formatHealth(%d) = %q, want %q", score, got, expected)
+		}
+	}
+}
+
+func TestChangesMarkdown(t *testing.T) {
+	server, _ := setupTest(t)
+	server.AddBuild("svc-web", &jenkinstest.Build{Number: 1, Result: "SUCCESS", ChangeSets: []jenkinstest.Change{
+		{CommitId: "1111111aaaa", Comment: "First", Author: "Alice", AffectedPaths: []string{"a.go"}},
+	}})
+	server.AddBuild("svc-web", &jenkinstest.Build{Number: 2, Result: "SUCCESS", ChangeSets: []jenkinstest.Change{
+		{CommitId: "2222222bbbb", Comment: "Second", Author: "Bob"},
+		{CommitId: "1111111aaaa", Comment: "First", Author: "Alice"},
+	}})
+
+	output := runCommand(t, "changes", "svc-web", "--from", "1", "--to", "2", "--markdown", "--account", "default")
+	expected := "## svc-web #1 – #2\n\n" +
+		"### Build #2\n\n- Second (`2222222`, Bob, -)\n- First (`1111111`, Alice, -)\n\n"
+	if output != expected {
+		t.Errorf("changelog =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestStatusSingleBuild(t *testing.T) {
+	server, _ := setupTest(t)
+	server.AddBuild("svc-api", &jenkinstest.Build{
+		Number: 3, Result: "SUCCESS", Duration: 45000, Timestamp: 1700000200000,
+		BuiltOn: "agent-1", Description: "release candidate",
+		Params:     map[string]string{"tag": "main", "pro": "dev"},
+		Causes:     []jenkinstest.Cause{{UserId: "tester", UserName: "Tester"}},
+		ChangeSets: []jenkinstest.Change{{CommitId: "3333333cccc", Comment: "Fix login", Author: "Carol"}},
+	})
+
+	output := runCommand(t, "status", "svc-api", "lastSuccessful", "--account", "default")
+	for _, expected := range []string{"svc-api #3", "SUCCESS", "release candidate", "agent-1", "Tester", "pro=dev", "tag=main", "Fix login"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("status output misses %q:\n%s", expected, output)
+		}
+	}
+	if strings.Index(output, "pro=dev") > strings.Index(output, "tag=main") {
+		t.Errorf("parameters are not sorted:\n%s", output)
+	}
+
+	output = runCommand(t, "status", "svc-api", "lastFailed", "--account", "default")
+	if !strings.Contains(output, "svc-api #2") || !strings.Contains(output, "FAILURE") {
+		t.Errorf("lastFailed status =\n%s", output)
+	}
+
+	for _, args := range [][]string{{"status", "svc-api"}, {"status", "svc-api", "latest"}, {"status", "svc-api", "1", "--watch"}} {
+		if _, err := executeCommand(t, append(args, "--account", "default")...); exitCode(err) != exitUsage {
+			t.Errorf("%v exit code = %d, want %d", args, exitCode(err), exitUsage)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/fatih/color"
 	"github.com/lemonsoul/jenkins-cli/api"
 	"github.com/lemonsoul/jenkins-cli/util"
@@ -9,25 +11,25 @@ import (
 
 var stopCmd = &cobra.Command{
 	Use:   "stop",
-	Short: "stop <jobName> <buildNumber>",
+	Short: "stop <jobName> <build>",
 	Long:  `jenkins-cli job stop`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) < 2 {
-			color.White("Please provide the job name and build number as arguments.")
-			return
+			return usageError("provide the job name and build number as arguments")
 		}
 		account, err := util.PickAccount("")
 		if err != nil {
-			color.Red("❌ Error loading account configuration: %v", err)
-			return
-		}
-		flag, err := api.Stop(account, args[0], args[1])
-		if flag {
-			color.Yellow("job [%s] stopped successfully, build number is %s", args[0], args[1])
+			return fmt.Errorf("failed to load account configuration: %w", err)
 		}
+		buildNumber, err := resolveBuildArg(account, args[0], args[1])
 		if err != nil {
-			color.Red("❌ %s", err.Error())
+			return err
+		}
+		if _, err := api.Stop(account, args[0], buildNumber); err != nil {
+			return err
 		}
+		color.Yellow("job [%s] stopped successfully, build number is %s", args[0], buildNumber)
+		return nil
 	},
 }
 
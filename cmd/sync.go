@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/lemonsoul/jenkins-cli/api"
@@ -12,46 +15,62 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+const defaultSyncWorkers = 8
+
+type syncOptions struct {
+	Views   []string
+	Workers int
+	Full    bool
+	// StaleAfter forces a refetch of unchanged jobs last synced longer ago than this.
+	StaleAfter time.Duration
+	// Quiet suppresses progress output, used when syncing in the background.
+	Quiet bool
+}
+
 var syncCmd = &cobra.Command{
 	Use:   "sync [account]",
 	Short: "sync config",
 	Long:  `sync jenkins data to config file`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
-			color.Red("❌ Too many arguments, at most one account name is allowed")
-			return
+			return usageError("too many arguments, at most one account name is allowed")
 		}
+		opts := syncOptions{}
+		opts.Views, _ = cmd.Flags().GetStringSlice("view")
+		opts.Workers, _ = cmd.Flags().GetInt("workers")
+		opts.Full, _ = cmd.Flags().GetBool("full")
+
 		if len(args) == 1 {
 			accountName := strings.TrimSpace(args[0])
 			if accountName == "" {
-				color.Red("❌ Account name cannot be empty")
-				return
+				return usageError("account name cannot be empty")
 			}
 			account, err := util.GetAccountByName(accountName)
 			if err != nil {
-				color.Red("❌ Error loading account %s: %v", accountName, err)
-				return
+				return fmt.Errorf("failed to load account %s: %w", accountName, err)
 			}
-			if err := syncWorkspaceForAccount(account); err != nil {
-				color.Red("❌ Sync failed for account %s: %v", accountName, err)
+			if err := syncWorkspaceForAccount(account, opts); err != nil {
+				return fmt.Errorf("sync failed for account %s: %w", accountName, err)
 			}
-			return
+			return nil
 		}
 
 		accounts, err := util.ListAccounts()
 		if err != nil {
-			color.Red("❌ Error loading accounts: %v", err)
-			return
+			return fmt.Errorf("failed to load accounts: %w", err)
 		}
+		failures := make([]error, 0)
 		for _, account := range accounts {
-			if err := syncWorkspaceForAccount(account); err != nil {
+			if err := syncWorkspaceForAccount(account, opts); err != nil {
 				color.Red("❌ Sync failed for account %s: %v", account.Name, err)
+				failures = append(failures, err)
 			}
 		}
+		return partialError(failures, len(accounts))
 	},
 }
 
-func syncWorkspaceForAccount(account config.JenkinsConfig) error {
+func syncWorkspaceForAccount(account config.JenkinsConfig, opts syncOptions) error {
 	cfg, err := util.GetWorkspaceFile(account.Name)
 	if err != nil {
 		// If workspace file doesn't exist, create empty workspace
@@ -59,76 +78,278 @@ func syncWorkspaceForAccount(account config.JenkinsConfig) error {
 		cfg = config.Workspace{Views: make([]config.View, 0)}
 	}
 
-	viewNames, err := api.GetViews(account)
+	cfg, err = syncWorkspace(account, cfg, opts)
 	if err != nil {
 		return err
 	}
 
-	// Reset views to get fresh data
-	oldCfg := cfg
-	cfg.Views = make([]config.View, 0)
-	cfg.RecentViews = util.FilterRecent(cfg.RecentViews, util.BuildAllowSet(viewNames), 3)
+	// Write the updated config back to the file
+	if err := saveWorkspaceFile(account.Name, cfg); err != nil {
+		return err
+	}
 
-	for _, viewName := range viewNames {
-		view := config.View{}
-		view.Name = viewName
-		view.Job = make([]config.Job, 0)
+	color.Green("✅ Workspace configuration synced successfully!")
+	return nil
+}
 
-		jobNames, err := api.GetViewJob(account, viewName)
-		if err != nil {
-			color.Yellow("⚠️ Error getting jobs for view %s: %v", viewName, err)
-			continue
+// syncWorkspace fetches fresh view and job data for account and merges it into cfg,
+// keeping the recent selections that still exist.
+func syncWorkspace(account config.JenkinsConfig, cfg config.Workspace, opts syncOptions) (config.Workspace, error) {
+	account.RefreshCache = true
+	summaries, err := fetchViewSummaries(account, opts)
+	if err != nil {
+		return cfg, err
+	}
+
+	now := time.Now()
+	oldCfg := cfg
+	depth := util.GetRecentDepth(cfg)
+	jobs := fetchChangedJobs(account, oldCfg, flattenViewSummaries(summaries), opts)
+
+	var buildView func(summary config.ViewSummary) config.View
+	buildView = func(summary config.ViewSummary) config.View {
+		view := config.View{Name: summary.Name, Job: make([]config.Job, 0, len(summary.Jobs)), SyncedAt: now}
+		jobNames := make([]string, 0, len(summary.Jobs))
+		for _, jobSummary := range summary.Jobs {
+			jobNames = append(jobNames, jobSummary.Name)
+			job := jobs[jobSummary.Name]
+			if existingJob, ok := findJob(oldCfg, summary.Name, jobSummary.Name); ok {
+				job.RecentChoices = util.FilterRecent(existingJob.RecentChoices, util.BuildAllowSet(job.JobParam.Choices), depth)
+				job.RecentBranches = util.FilterRecent(existingJob.RecentBranches, util.BuildAllowSet(job.JobParam.Branch), depth)
+			}
+			view.Job = append(view.Job, job)
 		}
-		view.RecentJobs = filterViewRecentJobs(oldCfg, viewName, jobNames)
+		view.RecentJobs = filterViewRecentJobs(oldCfg, summary.Name, jobNames)
+		for _, nested := range summary.Views {
+			view.Views = append(view.Views, buildView(nested))
+		}
+		return view
+	}
+	fresh := make([]config.View, 0, len(summaries))
+	for _, summary := range summaries {
+		fresh = append(fresh, buildView(summary))
+	}
 
-		for _, jobName := range jobNames {
-			jobParam := config.JobParam{}
-			existingJob, ok := findJob(oldCfg, viewName, jobName)
-			choices, branches, err := api.GetJobParams(account, jobName)
-			if err != nil {
-				color.Yellow("⚠️ Error getting job params for %s: %v", jobName, err)
-				if ok {
-					jobParam = existingJob.JobParam
-				}
+	if len(opts.Views) > 0 {
+		// Partial sync: replace the synced views in place and keep the rest untouched.
+		cfg.Views = copyViewTree(cfg.Views)
+		for _, view := range fresh {
+			if existing := util.FindView(&cfg, view.Name); existing != nil {
+				*existing = view
+			} else if parent := util.FindView(&cfg, api.ViewParentName(view.Name)); parent != nil {
+				parent.Views = append(parent.Views, view)
 			} else {
-				jobParam.Choices = choices
-				jobParam.Branch = branches
+				cfg.Views = append(cfg.Views, view)
 			}
-			job := config.Job{Name: jobName, JobParam: jobParam}
-			if ok {
-				choiceSet := util.BuildAllowSet(jobParam.Choices)
-				branchSet := util.BuildAllowSet(jobParam.Branch)
-				job.RecentChoices = util.FilterRecent(existingJob.RecentChoices, choiceSet, 3)
-				job.RecentBranches = util.FilterRecent(existingJob.RecentBranches, branchSet, 3)
-			}
-			view.Job = append(view.Job, job)
 		}
-		cfg.Views = append(cfg.Views, view)
+	} else {
+		cfg.Views = fresh
+		cfg.RecentViews = util.FilterRecent(cfg.RecentViews, util.BuildAllowSet(util.AllViewNames(&cfg)), depth)
+		cfg.SyncedAt = now
+	}
+	return cfg, nil
+}
+
+// copyViewTree copies the view nodes so that a partial sync does not write through
+// to the workspace it was given.
+func copyViewTree(views []config.View) []config.View {
+	copied := make([]config.View, len(views))
+	for index, view := range views {
+		view.Views = copyViewTree(view.Views)
+		copied[index] = view
+	}
+	return copied
+}
+
+func flattenViewSummaries(summaries []config.ViewSummary) []config.ViewSummary {
+	flat := make([]config.ViewSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		flat = append(flat, summary)
+		flat = append(flat, flattenViewSummaries(summary.Views)...)
 	}
+	return flat
+}
 
+// saveWorkspaceFile writes through a temporary file so an interrupted write never
+// leaves a truncated workspace behind.
+func saveWorkspaceFile(accountName string, cfg config.Workspace) error {
 	data, err := yaml.Marshal(&cfg)
 	if err != nil {
 		return err
 	}
+	workspacePath := util.GetWorkspaceFilePathByName(accountName)
+	tmpPath := workspacePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, workspacePath)
+}
 
-	workspacePath := util.GetWorkspaceFilePathByName(account.Name)
+// fetchViewSummaries prefers the single tree query and falls back to one request
+// per view for servers that reject it, failing rather than writing a tree missing
+// views. A partial sync only asks for its views.
+func fetchViewSummaries(account config.JenkinsConfig, opts syncOptions) ([]config.ViewSummary, error) {
+	if len(opts.Views) > 0 {
+		summaries := make([]config.ViewSummary, 0, len(opts.Views))
+		for _, viewName := range opts.Views {
+			summary, err := api.GetViewWithJobs(account, viewName)
+			if err != nil {
+				return nil, fmt.Errorf("view %s: %w", viewName, err)
+			}
+			summaries = append(summaries, summary)
+		}
+		return summaries, nil
+	}
 
-	// Write the updated config back to the file
-	err = os.WriteFile(workspacePath, data, 0644)
+	summaries, err := api.GetViewsWithJobs(account)
+	if err == nil {
+		return summaries, nil
+	}
+	if !opts.Quiet {
+		color.Yellow("⚠️ Tree query failed, falling back to per-view requests: %v", err)
+	}
+
+	// GetViews lists nested views after their parent, which holds them once
+	// every view has been fetched.
+	viewNames, err := api.GetViews(account)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	views := make(map[string]config.ViewSummary, len(viewNames))
+	children := make(map[string][]string)
+	for _, viewName := range viewNames {
+		jobNames, err := api.GetViewJob(account, viewName)
+		if err != nil {
+			return nil, fmt.Errorf("view %s: %w", viewName, err)
+		}
+		summary := config.ViewSummary{Name: viewName, Jobs: make([]config.JobSummary, 0, len(jobNames))}
+		for _, jobName := range jobNames {
+			summary.Jobs = append(summary.Jobs, config.JobSummary{Name: jobName})
+		}
+		views[viewName] = summary
+		parent := api.ViewParentName(viewName)
+		children[parent] = append(children[parent], viewName)
+	}
+	var nest func(parent string) []config.ViewSummary
+	nest = func(parent string) []config.ViewSummary {
+		var nested []config.ViewSummary
+		for _, viewName := range children[parent] {
+			summary := views[viewName]
+			summary.Views = nest(viewName)
+			nested = append(nested, summary)
+		}
+		return nested
+	}
+	return nest(""), nil
+}
 
-	color.Green("✅ Workspace configuration synced successfully!")
-	return nil
+// fetchChangedJobs resolves the parameters of every distinct job in summaries.
+// Jobs whose change marker matches the cached one are reused unless they are older
+// than opts.StaleAfter; the rest are fetched by a bounded pool of workers.
+func fetchChangedJobs(account config.JenkinsConfig, oldCfg config.Workspace, summaries []config.ViewSummary, opts syncOptions) map[string]config.Job {
+	now := time.Now()
+	jobs := make(map[string]config.Job)
+	pending := make([]config.JobSummary, 0)
+	for _, summary := range summaries {
+		for _, jobSummary := range summary.Jobs {
+			if _, seen := jobs[jobSummary.Name]; seen {
+				continue
+			}
+			job := config.Job{Name: jobSummary.Name, Marker: jobSummary.Marker, Color: jobSummary.Color, SyncedAt: now}
+			existingJob, ok := findJobInWorkspace(oldCfg, jobSummary.Name)
+			if ok {
+				job.JobParam = existingJob.JobParam
+			}
+			jobs[jobSummary.Name] = job
+			if !opts.Full && ok && jobSummary.Marker != "" && existingJob.Marker == jobSummary.Marker &&
+				!isStale(existingJob.SyncedAt, opts.StaleAfter) {
+				// Params were not refetched, so they are as old as the cached copy.
+				job.SyncedAt = existingJob.SyncedAt
+				jobs[jobSummary.Name] = job
+				continue
+			}
+			pending = append(pending, jobSummary)
+		}
+	}
+	if !opts.Quiet {
+		color.Cyan("🔍 %s: %d jobs, %d changed", account.Name, len(jobs), len(pending))
+	}
+	if len(pending) == 0 {
+		return jobs
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = defaultSyncWorkers
+	}
+	workers = min(workers, len(pending))
+
+	type jobResult struct {
+		name     string
+		choices  []string
+		branches []string
+		err      error
+	}
+	tasks := make(chan config.JobSummary)
+	results := make(chan jobResult)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range tasks {
+				choices, branches, err := api.GetJobParams(account, task.Name)
+				results <- jobResult{name: task.Name, choices: choices, branches: branches, err: err}
+			}
+		}()
+	}
+	go func() {
+		for _, task := range pending {
+			tasks <- task
+		}
+		close(tasks)
+		wg.Wait()
+		close(results)
+	}()
+
+	var bar *util.ProgressBar
+	if !opts.Quiet {
+		bar = util.NewProgressBar("📥 Syncing jobs", len(pending))
+	}
+	failures := make([]jobResult, 0)
+	for result := range results {
+		if bar != nil {
+			bar.Increment()
+		}
+		job := jobs[result.name]
+		if result.err != nil {
+			// Keep the cached params and drop the marker so the job is retried next time.
+			job.Marker = ""
+			job.SyncedAt = time.Time{}
+			failures = append(failures, result)
+		} else {
+			job.JobParam = config.JobParam{Choices: result.choices, Branch: result.branches}
+		}
+		jobs[result.name] = job
+	}
+	if bar != nil {
+		bar.Finish()
+		for _, failure := range failures {
+			color.Yellow("⚠️ Error getting job params for %s: %v", failure.name, failure.err)
+		}
+	}
+	return jobs
 }
 
 func init() {
 	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().StringSlice("view", nil, "only sync the given views (repeatable)")
+	syncCmd.Flags().Int("workers", defaultSyncWorkers, "number of concurrent job requests")
+	syncCmd.Flags().Bool("full", false, "refetch every job, ignoring change markers")
 }
 
 func findJob(cfg config.Workspace, viewName, jobName string) (config.Job, bool) {
-	for _, view := range cfg.Views {
+	for _, view := range util.AllViews(&cfg) {
 		if view.Name != viewName {
 			continue
 		}
@@ -141,12 +362,57 @@ func findJob(cfg config.Workspace, viewName, jobName string) (config.Job, bool)
 	return config.Job{}, false
 }
 
+func findJobInWorkspace(cfg config.Workspace, jobName string) (config.Job, bool) {
+	for _, view := range util.AllViews(&cfg) {
+		for _, job := range view.Job {
+			if job.Name == jobName {
+				return job, true
+			}
+		}
+	}
+	return config.Job{}, false
+}
+
+// updateJobInWorkspace applies update to every copy of jobName across views and
+// reports whether the job was found.
+func updateJobInWorkspace(cfg *config.Workspace, jobName string, update func(job *config.Job)) bool {
+	found := false
+	for _, view := range util.AllViews(cfg) {
+		for jobIndex := range view.Job {
+			if view.Job[jobIndex].Name == jobName {
+				update(&view.Job[jobIndex])
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+func findJobView(cfg config.Workspace, jobName string) string {
+	for _, view := range util.AllViews(&cfg) {
+		for _, job := range view.Job {
+			if job.Name == jobName {
+				return view.Name
+			}
+		}
+	}
+	return ""
+}
+
 func filterViewRecentJobs(cfg config.Workspace, viewName string, allow []string) []string {
+	depth := util.GetRecentDepth(cfg)
 	allowSet := util.BuildAllowSet(allow)
-	for _, view := range cfg.Views {
+	for _, view := range util.AllViews(&cfg) {
 		if view.Name == viewName {
-			return util.FilterRecent(view.RecentJobs, allowSet, 3)
+			return util.FilterRecent(view.RecentJobs, allowSet, depth)
 		}
 	}
 	return nil
 }
+
+func isStale(syncedAt time.Time, ttl time.Duration) bool {
+	if ttl <= 0 {
+		return false
+	}
+	return syncedAt.IsZero() || time.Since(syncedAt) > ttl
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+	"github.com/lemonsoul/jenkins-cli/util"
+)
+
+func TestSyncBuildsViewTree(t *testing.T) {
+	setupTest(t)
+	output := runCommand(t, "sync", "default")
+	if !strings.Contains(output, "synced successfully") {
+		t.Fatalf("sync output:\n%s", output)
+	}
+
+	workspaceCfg := loadTestWorkspace(t)
+	if names := util.AllViewNames(&workspaceCfg); !reflect.DeepEqual(names, []string{"all", "team", "team/backend"}) {
+		t.Errorf("synced views = %v", names)
+	}
+	job, ok := findJob(workspaceCfg, "team/backend", "svc-api")
+	if !ok {
+		t.Fatal("svc-api missing from team/backend")
+	}
+	if !reflect.DeepEqual(job.JobParam.Choices, []string{"dev", "prod"}) || !reflect.DeepEqual(job.JobParam.Branch, []string{"main", "release"}) {
+		t.Errorf("synced params = %+v", job.JobParam)
+	}
+	if job.Marker == "" {
+		t.Error("synced job has no change marker")
+	}
+}
+
+func TestSyncSkipsUnchangedJobs(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+	before := len(server.RequestsTo(http.MethodGet, "/job/svc-api/api/json"))
+
+	runCommand(t, "sync", "default")
+	if after := len(server.RequestsTo(http.MethodGet, "/job/svc-api/api/json")); after != before {
+		t.Errorf("unchanged job fetched again, %d requests after %d", after, before)
+	}
+
+	server.AddBuild("svc-api", &jenkinstest.Build{Result: "SUCCESS"})
+	runCommand(t, "sync", "default")
+	after := len(server.RequestsTo(http.MethodGet, "/job/svc-api/api/json"))
+	if after == before {
+		t.Error("changed job was not fetched again")
+	}
+
+	// New branches show up without a new build.
+	job := server.Job("svc-api")
+	server.Update(func() { job.Branches = append(job.Branches, "feature/login") })
+	runCommand(t, "sync", "default")
+	if len(server.RequestsTo(http.MethodGet, "/job/svc-api/api/json")) == after {
+		t.Error("job with new branches was not fetched again")
+	}
+	synced, _ := findJob(loadTestWorkspace(t), "team/backend", "svc-api")
+	if !slices.Contains(synced.JobParam.Branch, "feature/login") {
+		t.Errorf("branches after sync = %v", synced.JobParam.Branch)
+	}
+}
+
+func TestSyncFolderViews(t *testing.T) {
+	server, _ := setupTest(t)
+	server.AddFolder("platform")
+	view := server.AddView("platform » backend", "svc-web")
+	runCommand(t, "sync", "default")
+	workspaceCfg := loadTestWorkspace(t)
+	if _, ok := findJob(workspaceCfg, "platform » backend", "svc-web"); !ok {
+		t.Fatalf("folder view missing after sync: %v", util.AllViewNames(&workspaceCfg))
+	}
+
+	server.Update(func() { view.Jobs = append(view.Jobs, "infra") })
+	runCommand(t, "sync", "default", "--view", "platform » backend")
+	if _, ok := findJob(loadTestWorkspace(t), "platform » backend", "infra"); !ok {
+		t.Error("partial sync of the folder view missed its new job")
+	}
+}
+
+func TestBuildInFolderView(t *testing.T) {
+	server, _ := setupTest(t)
+	server.AddFolder("platform")
+	server.AddJob(&jenkinstest.Job{Name: "platform/deploy", Builds: []*jenkinstest.Build{{Number: 1, Result: "SUCCESS", Params: map[string]string{"pro": "dev", "tag": "main"}}}})
+	server.AddView("platform » backend", "platform/deploy")
+	runCommand(t, "sync", "default")
+	if _, ok := findJob(loadTestWorkspace(t), "platform » backend", "platform/deploy"); !ok {
+		t.Fatal("folder job missing from the folder view after sync")
+	}
+
+	runCommand(t, "preset", "create", "deploy", "--view", "platform » backend", "--job", "platform/deploy", "--from-build", "1", "--account", "default")
+	runCommand(t, "preset", "run", "deploy", "--account", "default")
+	if build := server.Build("platform/deploy", 2); build == nil || build.Params["pro"] != "dev" {
+		t.Errorf("build through the folder view = %+v", build)
+	}
+}
+
+func TestSyncFallbackPerView(t *testing.T) {
+	server, _ := setupTest(t)
+	server.AddFolder("platform")
+	server.AddView("platform » ops", "infra")
+	server.AddView("platform » ops/nightly", "svc-web")
+	server.Fail(http.MethodGet, "/api/json", http.StatusBadRequest, 1)
+	runCommand(t, "sync", "default")
+
+	workspaceCfg := loadTestWorkspace(t)
+	names := util.AllViewNames(&workspaceCfg)
+	if !reflect.DeepEqual(names, []string{"all", "team", "team/backend", "platform » ops", "platform » ops/nightly"}) {
+		t.Fatalf("views after the fallback sync = %v", names)
+	}
+	if ops := util.FindView(&workspaceCfg, "platform » ops"); len(workspaceCfg.Views) != 3 || len(ops.Views) != 1 {
+		t.Errorf("fallback sync left %d top level views, want the nested views inside their parents", len(workspaceCfg.Views))
+	}
+	if _, ok := findJob(workspaceCfg, "platform » ops/nightly", "svc-web"); !ok {
+		t.Error("nested folder view synced without its job")
+	}
+
+	// A view failing in the fallback fails the sync instead of dropping the view.
+	server.AddView("team/frontend", "svc-web")
+	server.Fail(http.MethodGet, "/api/json", http.StatusBadRequest, 1)
+	server.Fail(http.MethodGet, "/view/team/view/frontend/api/json", http.StatusForbidden, 1)
+	if _, err := executeCommand(t, "sync", "default"); err == nil {
+		t.Fatal("sync succeeded although a view could not be fetched")
+	}
+	workspaceCfg = loadTestWorkspace(t)
+	if after := util.AllViewNames(&workspaceCfg); !reflect.DeepEqual(after, names) {
+		t.Errorf("failed sync changed the workspace views to %v", after)
+	}
+}
+
+func TestPartialSyncKeepsOtherViews(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+	server.AddView("ops", "infra")
+	server.AddView("team/frontend", "svc-web")
+
+	runCommand(t, "sync", "default", "--view", "team")
+	workspaceCfg := loadTestWorkspace(t)
+	names := util.AllViewNames(&workspaceCfg)
+	if !reflect.DeepEqual(names, []string{"all", "team", "team/backend", "team/frontend"}) {
+		t.Errorf("views after partial sync = %v", names)
+	}
+}
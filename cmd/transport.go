@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+// traceOutput is the --trace-file of the running command, closed by closeTraceFile
+// once the command returned.
+var traceOutput *os.File
+
+func addTransportFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String("record", "", "record every Jenkins request and response into this directory, credentials redacted")
+	cmd.PersistentFlags().String("replay", "", "answer Jenkins requests from a directory written by --record instead of the network")
+	cmd.MarkFlagsMutuallyExclusive("record", "replay")
+	cmd.PersistentFlags().BoolP("verbose", "v", false, "log method, URL, status, latency and size of every Jenkins request to stderr")
+	cmd.PersistentFlags().Bool("debug", false, "like --verbose, with redacted headers and truncated bodies")
+	cmd.PersistentFlags().String("trace-file", "", "append a --debug trace of every Jenkins request to this file")
+	cmd.PersistentFlags().Bool("no-cache", false, "ask Jenkins instead of answering from the local response cache")
+	cmd.PersistentPreRunE = setupTransport
+}
+
+// setupTransport builds the api transport the flags ask for: a cassette recorder or
+// replayer, or else the response cache, wrapped in a tracer when requests are
+// logged. Cassettes bypass the cache so they hold exactly what Jenkins answered.
+func setupTransport(cmd *cobra.Command, args []string) error {
+	recordDir, _ := cmd.Flags().GetString("record")
+	replayDir, _ := cmd.Flags().GetString("replay")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	debug, _ := cmd.Flags().GetBool("debug")
+	traceFile, _ := cmd.Flags().GetString("trace-file")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	if err := closeTraceFile(); err != nil {
+		return err
+	}
+
+	var transport http.RoundTripper
+	switch {
+	case recordDir != "":
+		recorder, err := api.NewRecorder(recordDir, http.DefaultTransport)
+		if err != nil {
+			return err
+		}
+		transport = recorder
+	case replayDir != "":
+		replayer, err := api.NewReplayer(replayDir)
+		if err != nil {
+			return err
+		}
+		transport = replayer
+	case !noCache:
+		transport = api.NewCache(util.GetCacheDirPath(), config.DEFAULT_CACHE_TTL, nil)
+	}
+
+	if verbose || debug || traceFile != "" {
+		tracer := api.NewTracer(transport)
+		if verbose || debug {
+			tracer.AddOutput(os.Stderr, debug)
+		}
+		if traceFile != "" {
+			file, err := os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+			if err != nil {
+				return fmt.Errorf("failed to open trace file: %w", err)
+			}
+			traceOutput = file
+			tracer.AddOutput(file, true)
+		}
+		transport = tracer
+	}
+	api.SetTransport(transport)
+	return nil
+}
+
+// closeTraceFile flushes and closes the trace file, whether the command succeeded or
+// not.
+func closeTraceFile() error {
+	if traceOutput == nil {
+		return nil
+	}
+	file := traceOutput
+	traceOutput = nil
+	syncErr := file.Sync()
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close trace file: %w", err)
+	}
+	if syncErr != nil {
+		return fmt.Errorf("failed to write trace file: %w", syncErr)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTraceFileClosedOnError(t *testing.T) {
+	setupTest(t)
+	traceFile := filepath.Join(t.TempDir(), "trace.log")
+	if _, err := executeCommand(t, "chain", "svc-api", "99", "--account", "default", "--trace-file", traceFile); exitCode(err) != exitNotFound {
+		t.Fatalf("chain of a missing build = %v", err)
+	}
+	if traceOutput != nil {
+		t.Error("trace file left open")
+	}
+	data, err := os.ReadFile(traceFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "/job/svc-api/99/api/json") {
+		t.Errorf("trace misses the failed request:\n%s", data)
+	}
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/tui"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "tui",
+	Long:  `full-screen interface with the jobs, running builds, queue, stages and log of the selected build`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		entries := collectJobEntries(workspaceCfg)
+		jobs := make([]tui.JobItem, 0, len(entries))
+		for _, entry := range entries {
+			item := tui.JobItem{Name: entry.Name, View: entry.Views[0], Color: entry.Color}
+			if job, ok := findJob(workspaceCfg, item.View, item.Name); ok {
+				item.Choices, item.Branches = job.JobParam.Choices, job.JobParam.Branch
+				if len(job.RecentChoices) > 0 {
+					item.RecentChoice = job.RecentChoices[0]
+				}
+				if len(job.RecentBranches) > 0 {
+					item.RecentBranch = job.RecentBranches[0]
+				}
+			}
+			jobs = append(jobs, item)
+		}
+
+		return tui.Run(tui.Options{
+			Account:  account,
+			Jobs:     jobs,
+			Interval: interval,
+			OnBuild: func(job tui.JobItem, params map[string]string) {
+				updateWorkspaceRecent(&workspaceCfg, account.Name, job.View, job.Name, params[config.PARAM_CHOICE], params[config.PARAM_BRANCH])
+			},
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	tuiCmd.Flags().String("account", "", "account name")
+	tuiCmd.Flags().Duration("interval", 3*time.Second, "refresh interval")
+}
@@ -12,11 +12,12 @@ var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show the jenkins-cli version information",
 	Long:  `Display the version, commit, and build date for the jenkins-cli binary`,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		info := appversion.Info()
 		fmt.Printf("Version: %s\n", info["version"])
 		fmt.Printf("Commit: %s\n", info["commit"])
 		fmt.Printf("Build Date: %s\n", info["buildDate"])
+		return nil
 	},
 }
 
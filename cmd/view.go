@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+var includeRegexPattern = regexp.MustCompile(`(?s)\s*<includeRegex>.*?</includeRegex>`)
+
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "view list|create|add|remove|regex|delete",
+	Long:  `manage jenkins views and their jobs`,
+}
+
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list views with job counts and health",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		summaries, err := api.GetViewsWithJobs(account)
+		if err != nil {
+			return fmt.Errorf("failed to get views: %w", err)
+		}
+		if len(summaries) == 0 {
+			color.White("🥚  No views")
+			return nil
+		}
+		// Nested views are indented below the view containing them.
+		summaries = flattenViewSummaries(summaries)
+		labels := make([]string, 0, len(summaries))
+		width := 0
+		for _, summary := range summaries {
+			label := strings.Repeat("  ", strings.Count(summary.Name, api.ViewSeparator)) + viewLabel(summary.Name)
+			labels = append(labels, label)
+			width = max(width, utf8.RuneCountInString(label))
+		}
+		for index, summary := range summaries {
+			fmt.Printf("%s  %3d jobs  %s\n", color.CyanString("%-*s", width, labels[index]), len(summary.Jobs), formatViewHealth(summary))
+		}
+		return nil
+	},
+}
+
+var viewCreateCmd = &cobra.Command{
+	Use:   "create <viewName>",
+	Short: "create a list view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the view name as argument")
+		}
+		jobNames, _ := cmd.Flags().GetStringSlice("job")
+		includeRegex, _ := cmd.Flags().GetString("regex")
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		viewName := args[0]
+		if err := api.CreateListView(account, viewName); err != nil {
+			return fmt.Errorf("failed to create view: %w", err)
+		}
+		color.Green("✅ View %s created", viewName)
+		failures := make([]error, 0)
+		for _, jobName := range jobNames {
+			if err := api.AddJobToView(account, viewName, jobName); err != nil {
+				color.Red("❌ Error adding %s to %s: %v", jobName, viewName, err)
+				failures = append(failures, err)
+				continue
+			}
+			color.Green("✅ %s added to %s", jobName, viewName)
+		}
+		if includeRegex != "" {
+			if err := updateViewRegex(account, viewName, includeRegex); err != nil {
+				return fmt.Errorf("failed to set the regex of %s: %w", viewName, err)
+			}
+		}
+		refreshWorkspaceView(account, &workspaceCfg, viewName)
+		return partialError(failures, len(jobNames))
+	},
+}
+
+var viewAddCmd = &cobra.Command{
+	Use:   "add <viewName> <jobName...>",
+	Short: "add jobs to a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateViewMembership(cmd, args, true)
+	},
+}
+
+var viewRemoveCmd = &cobra.Command{
+	Use:   "remove <viewName> <jobName...>",
+	Short: "remove jobs from a view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateViewMembership(cmd, args, false)
+	},
+}
+
+var viewRegexCmd = &cobra.Command{
+	Use:   "regex <viewName> [regex]",
+	Short: "show or change the job filter regex of a list view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the view name as argument")
+		}
+		clearRegex, _ := cmd.Flags().GetBool("clear")
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		viewName := args[0]
+		if len(args) < 2 && !clearRegex {
+			configXml, err := api.GetViewConfig(account, viewName)
+			if err != nil {
+				return fmt.Errorf("failed to get view config: %w", err)
+			}
+			if includeRegex, ok := viewIncludeRegex(configXml); ok {
+				fmt.Println(includeRegex)
+			} else {
+				color.White("🥚  View %s has no regex filter", viewName)
+			}
+			return nil
+		}
+		includeRegex := ""
+		if !clearRegex {
+			includeRegex = args[1]
+		}
+		if err := updateViewRegex(account, viewName, includeRegex); err != nil {
+			return fmt.Errorf("failed to update view config: %w", err)
+		}
+		refreshWorkspaceView(account, &workspaceCfg, viewName)
+		return nil
+	},
+}
+
+var viewDeleteCmd = &cobra.Command{
+	Use:   "delete <viewName>",
+	Short: "delete a view, keeping its jobs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return usageError("provide the view name as argument")
+		}
+		yes, _ := cmd.Flags().GetBool("yes")
+		account, workspaceCfg, err := loadAccountWorkspace(cmd)
+		if err != nil {
+			return err
+		}
+		viewName := args[0]
+		if !yes && !util.Confirm(fmt.Sprintf("Delete view %s", viewName)) {
+			color.Yellow("⚠️ Aborted")
+			return nil
+		}
+		if err := api.DeleteView(account, viewName); err != nil {
+			return fmt.Errorf("failed to delete view: %w", err)
+		}
+		color.Green("✅ View %s deleted", viewName)
+		removeViewFromWorkspace(&workspaceCfg, viewName)
+		if err := saveWorkspaceFile(account.Name, workspaceCfg); err != nil {
+			return fmt.Errorf("failed to write workspace configuration: %w", err)
+		}
+		return nil
+	},
+}
+
+func updateViewMembership(cmd *cobra.Command, args []string, add bool) error {
+	if len(args) < 2 {
+		return usageError("provide the view name and at least one job name as arguments")
+	}
+	account, workspaceCfg, err := loadAccountWorkspace(cmd)
+	if err != nil {
+		return err
+	}
+	viewName := args[0]
+	failures := make([]error, 0)
+	for _, jobName := range args[1:] {
+		if add {
+			if err := api.AddJobToView(account, viewName, jobName); err != nil {
+				color.Red("❌ Error adding %s to %s: %v", jobName, viewName, err)
+				failures = append(failures, err)
+				continue
+			}
+			color.Green("✅ %s added to %s", jobName, viewName)
+		} else {
+			if err := api.RemoveJobFromView(account, viewName, jobName); err != nil {
+				color.Red("❌ Error removing %s from %s: %v", jobName, viewName, err)
+				failures = append(failures, err)
+				continue
+			}
+			color.Green("✅ %s removed from %s", jobName, viewName)
+		}
+	}
+	refreshWorkspaceView(account, &workspaceCfg, viewName)
+	return partialError(failures, len(args)-1)
+}
+
+// updateViewRegex replaces the includeRegex element of a list view, removing it when
+// includeRegex is empty.
+func updateViewRegex(account config.JenkinsConfig, viewName, includeRegex string) error {
+	if includeRegex != "" {
+		// Jenkins uses Java regular expressions, which accept a few constructs Go does not.
+		if _, err := regexp.Compile(includeRegex); err != nil {
+			color.Yellow("⚠️ %q may not be a valid regex: %v", includeRegex, err)
+		}
+	}
+	configXml, err := api.GetViewConfig(account, viewName)
+	if err != nil {
+		return err
+	}
+	updated, err := setViewIncludeRegex(configXml, includeRegex)
+	if err != nil {
+		return err
+	}
+	if err := api.UpdateViewConfig(account, viewName, updated); err != nil {
+		return err
+	}
+	if includeRegex == "" {
+		color.Green("✅ Regex filter of %s removed", viewName)
+	} else {
+		color.Green("✅ Regex filter of %s set to %s", viewName, includeRegex)
+	}
+	return nil
+}
+
+func viewIncludeRegex(configXml string) (string, bool) {
+	match := includeRegexPattern.FindString(configXml)
+	if match == "" {
+		return "", false
+	}
+	value := strings.TrimSpace(match)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "<includeRegex>"), "</includeRegex>")
+	return html.UnescapeString(value), true
+}
+
+func setViewIncludeRegex(configXml, includeRegex string) (string, error) {
+	element := ""
+	if includeRegex != "" {
+		element = "\n  <includeRegex>" + html.EscapeString(includeRegex) + "</includeRegex>"
+	}
+	if includeRegexPattern.MatchString(configXml) {
+		return includeRegexPattern.ReplaceAllLiteralString(configXml, element), nil
+	}
+	if element == "" {
+		return configXml, nil
+	}
+	closing := strings.LastIndex(configXml, "</")
+	if closing < 0 || !strings.Contains(configXml[:closing], "ListView") {
+		return "", fmt.Errorf("only list views have a regex filter")
+	}
+	return configXml[:closing] + strings.TrimPrefix(element, "\n") + "\n" + configXml[closing:], nil
+}
+
+// refreshWorkspaceView syncs a single view into the workspace so that it reflects a
+// change right away.
+func refreshWorkspaceView(account config.JenkinsConfig, workspaceCfg *config.Workspace, viewName string) {
+	synced, err := syncWorkspace(account, *workspaceCfg, syncOptions{Views: []string{viewName}, Quiet: true})
+	if err != nil {
+		color.Yellow("⚠️ Error refreshing view %s in workspace: %v", viewName, err)
+		return
+	}
+	*workspaceCfg = synced
+	if err := saveWorkspaceFile(account.Name, *workspaceCfg); err != nil {
+		color.Yellow("⚠️ Error writing workspace configuration: %v", err)
+	}
+}
+
+// removeViewFromWorkspace drops a view and its nested views from the tree and from
+// the recent views.
+func removeViewFromWorkspace(cfg *config.Workspace, viewName string) {
+	removed := func(view config.View) bool { return view.Name == viewName }
+	if parent := util.FindView(cfg, api.ViewParentName(viewName)); parent != nil {
+		parent.Views = slices.DeleteFunc(parent.Views, removed)
+	} else {
+		cfg.Views = slices.DeleteFunc(cfg.Views, removed)
+	}
+	cfg.RecentViews = slices.DeleteFunc(cfg.RecentViews, func(name string) bool {
+		return name == viewName || strings.HasPrefix(name, viewName+api.ViewSeparator)
+	})
+}
+
+// viewLabel names a view among its siblings: nested views by their own name, top
+// level views, which may belong to folders, by their full name.
+func viewLabel(viewName string) string {
+	if api.ViewParentName(viewName) == "" {
+		return viewName
+	}
+	return api.ViewBaseName(viewName)
+}
+
+func formatViewHealth(summary config.ViewSummary) string {
+	counts := make(map[string]int)
+	for _, job := range summary.Jobs {
+		counts[util.JobColorStatus(job.Color)]++
+	}
+	parts := make([]string, 0)
+	for _, status := range []string{"SUCCESS", "FAILURE", "UNSTABLE", "BUILDING", "ABORTED", "DISABLED", "NOT_BUILT", "UNKNOWN"} {
+		if counts[status] > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", util.ColorizeStatus(status), counts[status]))
+		}
+	}
+	built := len(summary.Jobs) - counts["DISABLED"] - counts["NOT_BUILT"] - counts["UNKNOWN"] - counts["BUILDING"]
+	health := "-"
+	if built > 0 {
+		health = fmt.Sprintf("%3d%%", counts["SUCCESS"]*100/built)
+	}
+	return fmt.Sprintf("health %s  %s", health, strings.Join(parts, "  "))
+}
+
+func init() {
+	rootCmd.AddCommand(viewCmd)
+	viewCmd.AddCommand(viewListCmd, viewCreateCmd, viewAddCmd, viewRemoveCmd, viewRegexCmd, viewDeleteCmd)
+	viewCmd.PersistentFlags().String("account", "", "account name")
+	viewCreateCmd.Flags().StringSlice("job", nil, "jobs to add to the view (repeatable)")
+	viewCreateCmd.Flags().String("regex", "", "job filter regex")
+	viewRegexCmd.Flags().Bool("clear", false, "remove the regex filter")
+	viewDeleteCmd.Flags().BoolP("yes", "y", false, "skip the confirmation prompt")
+}
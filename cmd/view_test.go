@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lemonsoul/jenkins-cli/util"
+)
+
+func TestViewCreateAddRemove(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+
+	output := runCommand(t, "view", "create", "team/frontend", "--job", "svc-web", "--account", "default")
+	if !strings.Contains(output, "View team/frontend created") {
+		t.Fatalf("view create output:\n%s", output)
+	}
+	if view := server.View("team/frontend"); view == nil || !reflect.DeepEqual(view.Jobs, []string{"svc-web"}) {
+		t.Fatalf("created view = %+v", view)
+	}
+	workspaceCfg := loadTestWorkspace(t)
+	if view := util.FindView(&workspaceCfg, "team/frontend"); view == nil || len(view.Job) != 1 {
+		t.Fatalf("workspace view = %+v", view)
+	}
+
+	runCommand(t, "view", "add", "team/frontend", "infra", "--account", "default")
+	runCommand(t, "view", "remove", "team/frontend", "svc-web", "--account", "default")
+	if jobs := server.View("team/frontend").Jobs; !reflect.DeepEqual(jobs, []string{"infra"}) {
+		t.Errorf("view jobs = %v", jobs)
+	}
+	workspaceCfg = loadTestWorkspace(t)
+	if view := util.FindView(&workspaceCfg, "team/frontend"); len(view.Job) != 1 || view.Job[0].Name != "infra" {
+		t.Errorf("workspace view jobs = %+v", view.Job)
+	}
+
+	runCommand(t, "view", "delete", "team/frontend", "-y", "--account", "default")
+	workspaceCfg = loadTestWorkspace(t)
+	if server.View("team/frontend") != nil || util.FindView(&workspaceCfg, "team/frontend") != nil {
+		t.Error("view was not deleted")
+	}
+}
+
+func TestViewRegex(t *testing.T) {
+	server, _ := setupTest(t)
+	runCommand(t, "sync", "default")
+
+	runCommand(t, "view", "regex", "team/backend", "svc-.*", "--account", "default")
+	if !strings.Contains(server.View("team/backend").Config, "<includeRegex>svc-.*</includeRegex>") {
+		t.Fatalf("view config = %s", server.View("team/backend").Config)
+	}
+	if output := runCommand(t, "view", "regex", "team/backend", "--account", "default"); strings.TrimSpace(output) != "svc-.*" {
+		t.Errorf("view regex output = %q", output)
+	}
+	runCommand(t, "view", "regex", "team/backend", "--clear", "--account", "default")
+	if strings.Contains(server.View("team/backend").Config, "includeRegex") {
+		t.Errorf("regex not cleared: %s", server.View("team/backend").Config)
+	}
+}
+
+func TestViewCreateWithRegex(t *testing.T) {
+	setupTest(t)
+	runCommand(t, "sync", "default")
+
+	runCommand(t, "view", "create", "services", "--regex", "svc-.*", "--account", "default")
+	workspaceCfg := loadTestWorkspace(t)
+	view := util.FindView(&workspaceCfg, "services")
+	if view == nil {
+		t.Fatalf("created view missing from the workspace: %v", util.AllViewNames(&workspaceCfg))
+	}
+	names := make([]string, 0, len(view.Job))
+	for _, job := range view.Job {
+		names = append(names, job.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"svc-api", "svc-web"}) {
+		t.Errorf("workspace jobs of the regex view = %v", names)
+	}
+}
+
+func TestSetViewIncludeRegex(t *testing.T) {
+	listView := "<hudson.model.ListView>\n  <name>x</name>\n</hudson.model.ListView>"
+	updated, err := setViewIncludeRegex(listView, "a&b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if regex, ok := viewIncludeRegex(updated); !ok || regex != "a&b" {
+		t.Errorf("regex after set = %q, %v in %s", regex, ok, updated)
+	}
+	cleared, _ := setViewIncludeRegex(updated, "")
+	if _, ok := viewIncludeRegex(cleared); ok {
+		t.Errorf("regex after clear in %s", cleared)
+	}
+	if _, err := setViewIncludeRegex("<hudson.model.AllView/>", "x"); err == nil {
+		t.Error("setting a regex on a view that is not a list view succeeded")
+	}
+}
@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+	"github.com/spf13/cobra"
+)
+
+const watchDefaultInterval = 10 * time.Second
+const watchMaxErrors = 5
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "watch <jobName> <build>",
+	Long: `wait for a build to finish and send its result to the notifiers of the account,
+use 'watch add' to hand the build to the background watcher instead`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the job name and build number as arguments")
+		}
+		interval, _ := cmd.Flags().GetDuration("interval")
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		buildNumber, err := resolveBuildArg(account, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return watchAndNotify(account, args[0], buildNumber, interval)
+	},
+}
+
+var watchAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "add <jobName> <build>",
+	Long:  `track a build in the background, the watcher keeps running after the terminal is closed`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return usageError("provide the job name and build number as arguments")
+		}
+		interval, _ := cmd.Flags().GetDuration("interval")
+		accountName, _ := cmd.Flags().GetString("account")
+		account, err := resolveAccount(accountName)
+		if err != nil {
+			return fmt.Errorf("failed to load account configuration: %w", err)
+		}
+		buildNumber, err := resolveBuildArg(account, args[0], args[1])
+		if err != nil {
+			return err
+		}
+		build, err := api.GetBuildSummary(account, args[0], buildNumber)
+		if err != nil {
+			return fmt.Errorf("failed to get build: %w", err)
+		}
+
+		added := false
+		state, err := util.UpdateWatchState(func(state *config.WatchState) {
+			for _, watched := range state.Builds {
+				if watched.Account == account.Name && watched.Job == build.JobName && watched.Number == build.Number {
+					return
+				}
+			}
+			state.Builds = append(state.Builds, config.WatchedBuild{
+				Account: account.Name,
+				Job:     build.JobName,
+				Number:  build.Number,
+				AddedAt: time.Now(),
+			})
+			added = true
+		})
+		if err != nil {
+			return fmt.Errorf("failed to save watch state: %w", err)
+		}
+		if !added {
+			color.Yellow("⚠️ %s #%d is already tracked", build.JobName, build.Number)
+		} else {
+			color.Green("✅ Tracking %s #%d", build.JobName, build.Number)
+		}
+		if err := ensureWatcher(state, interval); err != nil {
+			return fmt.Errorf("failed to start the watcher: %w", err)
+		}
+		return nil
+	},
+}
+
+var watchListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "list tracked builds",
+	Long:  `list the builds tracked by the background watcher and their results`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		state, err := util.LoadWatchState()
+		if err != nil {
+			return fmt.Errorf("failed to load watch state: %w", err)
+		}
+		if processAlive(state.Pid) {
+			color.Cyan("🔭 Watcher running (pid %d)", state.Pid)
+		} else if len(util.PendingWatches(state)) > 0 {
+			color.Yellow("⚠️ Watcher is not running, 'jenkins-cli watch add' restarts it")
+		}
+		if len(state.Builds) == 0 {
+			color.White("🥚  No tracked builds")
+			return nil
+		}
+		printWatchedBuilds(state.Builds)
+		return nil
+	},
+}
+
+var watchDaemonCmd = &cobra.Command{
+	Use:    "daemon",
+	Short:  "run the background watcher",
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		runWatcher(interval)
+		return nil
+	},
+}
+
+func printWatchedBuilds(builds []config.WatchedBuild) {
+	width := len("JOB")
+	for _, build := range builds {
+		width = max(width, len(build.Job))
+	}
+	color.HiBlack("  %-12s  %-*s  %-6s  %-14s  %-9s  %s", "ACCOUNT", width, "JOB", "BUILD", "RESULT", "DURATION", "ADDED")
+	for _, build := range builds {
+		status := watchedStatus(build)
+		fmt.Printf("  %-12s  %s  %-6s  %s%s  %-9s  %s\n",
+			build.Account,
+			color.CyanString("%-*s", width, build.Job),
+			"#"+strconv.Itoa(build.Number),
+			util.ColorizeStatus(status), strings.Repeat(" ", max(12-len(status), 0)),
+			formatWatchedDuration(build),
+			formatAgo(build.AddedAt.UnixMilli()))
+		if build.Error != "" {
+			color.HiBlack("  %s", build.Error)
+		}
+	}
+}
+
+func watchedStatus(build config.WatchedBuild) string {
+	switch {
+	case build.FinishedAt.IsZero():
+		return "BUILDING"
+	case build.Result == "":
+		return "UNKNOWN"
+	}
+	return build.Result
+}
+
+func formatWatchedDuration(build config.WatchedBuild) string {
+	if build.FinishedAt.IsZero() || build.Duration <= 0 {
+		return "-"
+	}
+	return (time.Duration(build.Duration) * time.Millisecond).Round(time.Second).String()
+}
+
+// ensureWatcher starts the background watcher unless the one recorded in state
+// is still alive. Its output goes to the watcher log.
+func ensureWatcher(state config.WatchState, interval time.Duration) error {
+	if processAlive(state.Pid) {
+		return nil
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(util.GetWatchLogFilePath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	command := exec.Command(executable, "watch", "daemon", "--interval", interval.String())
+	command.Stdout = logFile
+	command.Stderr = logFile
+	detachProcess(command)
+	if err := command.Start(); err != nil {
+		return err
+	}
+	color.Cyan("🔭 Watcher started (pid %d), log: %s", command.Process.Pid, util.GetWatchLogFilePath())
+	return command.Process.Release()
+}
+
+type watchKey struct {
+	Account string
+	Job     string
+	Number  int
+}
+
+// runWatcher polls the pending builds of the watch state until none is left. Only
+// one watcher runs at a time, it records itself as the pid of the state.
+func runWatcher(interval time.Duration) {
+	if interval <= 0 {
+		interval = watchDefaultInterval
+	}
+	self := os.Getpid()
+	state, err := util.UpdateWatchState(func(state *config.WatchState) {
+		if !processAlive(state.Pid) || state.Pid == self {
+			state.Pid = self
+		}
+	})
+	if err != nil {
+		log.Printf("error loading watch state: %v", err)
+		return
+	}
+	if state.Pid != self {
+		log.Printf("watcher %d is already running", state.Pid)
+		return
+	}
+	log.Printf("watcher %d started, polling every %s", self, interval)
+
+	accounts := make(map[string]config.JenkinsConfig)
+	for {
+		state, err := util.LoadWatchState()
+		if err != nil {
+			log.Printf("error loading watch state: %v", err)
+			time.Sleep(interval)
+			continue
+		}
+		pending := util.PendingWatches(state)
+		if len(pending) == 0 {
+			// Stop only if no build was added since loading, under the state lock.
+			stop := false
+			if _, err := util.UpdateWatchState(func(state *config.WatchState) {
+				if len(util.PendingWatches(*state)) == 0 {
+					state.Pid = 0
+					stop = true
+				}
+			}); err != nil {
+				log.Printf("error saving watch state: %v", err)
+				time.Sleep(interval)
+				continue
+			}
+			if stop {
+				log.Printf("watcher %d stopped, no builds left", self)
+				return
+			}
+			continue
+		}
+
+		results := make(map[watchKey]config.BuildSummary)
+		failures := make(map[watchKey]error)
+		for _, watched := range pending {
+			key := watchKey{watched.Account, watched.Job, watched.Number}
+			account, ok := accounts[watched.Account]
+			if !ok {
+				account, err = util.GetAccountByName(watched.Account)
+				if err != nil {
+					failures[key] = err
+					continue
+				}
+				accounts[watched.Account] = account
+			}
+			build, err := api.GetBuildSummary(account, watched.Job, strconv.Itoa(watched.Number))
+			if err != nil {
+				failures[key] = err
+				continue
+			}
+			results[key] = build
+		}
+
+		finished := make([]config.WatchedBuild, 0)
+		now := time.Now()
+		if _, err := util.UpdateWatchState(func(state *config.WatchState) {
+			for index := range state.Builds {
+				watched := &state.Builds[index]
+				key := watchKey{watched.Account, watched.Job, watched.Number}
+				if !watched.FinishedAt.IsZero() {
+					continue
+				}
+				if err, ok := failures[key]; ok {
+					watched.CheckedAt = now
+					watched.Error = err.Error()
+					watched.Failures++
+					// Builds that cannot be read are given up, e.g. deleted ones.
+					if watched.Failures >= watchMaxErrors {
+						watched.FinishedAt = now
+						log.Printf("giving up %s #%d: %v", watched.Job, watched.Number, err)
+					}
+					continue
+				}
+				build, ok := results[key]
+				if !ok {
+					continue
+				}
+				watched.CheckedAt = now
+				watched.Error = ""
+				watched.Failures = 0
+				if !build.Building && build.Result != "" {
+					watched.Result = build.Result
+					watched.Duration = build.Duration
+					watched.FinishedAt = now
+					finished = append(finished, *watched)
+				}
+			}
+		}); err != nil {
+			log.Printf("error saving watch state: %v", err)
+			time.Sleep(interval)
+			continue
+		}
+
+		for _, watched := range finished {
+			build := results[watchKey{watched.Account, watched.Job, watched.Number}]
+			log.Print(util.BuildMessage(build))
+			for _, err := range util.NotifyBuild(accounts[watched.Account], build) {
+				log.Printf("error sending notification: %v", err)
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+func addNotifyFlag(command *cobra.Command) {
+	command.Flags().Bool("notify", false, "wait for the build to finish and send a notification")
+}
+
+// notifyWhenFinished watches the triggered build when --notify is set.
+func notifyWhenFinished(cmd *cobra.Command, account config.JenkinsConfig, jobName, buildNumber string) error {
+	if notify, _ := cmd.Flags().GetBool("notify"); !notify {
+		return nil
+	}
+	if buildNumber == "" {
+		color.Yellow("⚠️ Build is still queued, use 'jenkins-cli watch %s <buildNumber>' once it started", jobName)
+		return nil
+	}
+	return watchAndNotify(account, jobName, buildNumber, watchDefaultInterval)
+}
+
+func watchAndNotify(account config.JenkinsConfig, jobName, buildNumber string, interval time.Duration) error {
+	color.Cyan("👀 Watching %s #%s, press Ctrl+C to stop", jobName, buildNumber)
+	build, err := waitForBuild(account, jobName, buildNumber, interval)
+	if err != nil {
+		return fmt.Errorf("failed to watch build: %w", err)
+	}
+	fmt.Println(util.BuildMessage(build))
+	for _, err := range util.NotifyBuild(account, build) {
+		color.Yellow("⚠️ Error sending notification: %v", err)
+	}
+	return nil
+}
+
+// waitForBuild polls the build until it has a result. A few failed polls in a row
+// are tolerated so a restarting Jenkins does not end the watch.
+func waitForBuild(account config.JenkinsConfig, jobName, buildNumber string, interval time.Duration) (config.BuildSummary, error) {
+	if interval <= 0 {
+		interval = watchDefaultInterval
+	}
+	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+	s.Start()
+	defer s.Stop()
+	failures := 0
+	for {
+		build, err := api.GetBuildSummary(account, jobName, buildNumber)
+		if err != nil {
+			failures++
+			if failures >= watchMaxErrors {
+				return config.BuildSummary{}, err
+			}
+		} else {
+			failures = 0
+			if !build.Building && build.Result != "" {
+				return build, nil
+			}
+			s.Suffix = fmt.Sprintf(" %s #%d running for %s", jobName, build.Number, formatBuildDuration(build))
+		}
+		time.Sleep(interval)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+	watchCmd.AddCommand(watchAddCmd, watchListCmd, watchDaemonCmd)
+	watchCmd.PersistentFlags().String("account", "", "account name")
+	for _, command := range []*cobra.Command{watchCmd, watchAddCmd, watchDaemonCmd} {
+		command.Flags().Duration("interval", watchDefaultInterval, "poll interval")
+	}
+}
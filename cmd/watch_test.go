@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/api/jenkinstest"
+	"github.com/lemonsoul/jenkins-cli/config"
+	"github.com/lemonsoul/jenkins-cli/util"
+)
+
+// newWebhook records the JSON payloads posted to it.
+func newWebhook(t *testing.T) (*httptest.Server, func() []map[string]any) {
+	t.Helper()
+	var mu sync.Mutex
+	payloads := make([]map[string]any, 0)
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := make(map[string]any)
+		json.NewDecoder(r.Body).Decode(&payload)
+		mu.Lock()
+		payloads = append(payloads, payload)
+		mu.Unlock()
+	}))
+	t.Cleanup(hook.Close)
+	return hook, func() []map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]any(nil), payloads...)
+	}
+}
+
+func TestWatchNotifiesWebhook(t *testing.T) {
+	server, account := setupTest(t)
+	hook, payloads := newWebhook(t)
+	account.Notifiers = []config.NotifierConfig{
+		{Type: config.NOTIFIER_WEBHOOK, Url: hook.URL},
+		{Type: config.NOTIFIER_SLACK, Url: hook.URL, On: []string{"FAILURE"}},
+	}
+	writeTestAccounts(t, account)
+	build := server.AddBuild("svc-web", &jenkinstest.Build{Number: 1, Building: true})
+	// Finish the build after the first poll.
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		server.Update(func() { build.Building, build.Result, build.Duration = false, "SUCCESS", 5000 })
+	}()
+
+	output := runCommand(t, "watch", "svc-web", "1", "--interval", "20ms", "--account", "default")
+	if !strings.Contains(output, "svc-web #1 SUCCESS in 5s") {
+		t.Errorf("watch output:\n%s", output)
+	}
+	received := payloads()
+	if len(received) != 1 {
+		t.Fatalf("payloads = %v, want only the webhook since slack is limited to failures", received)
+	}
+	if received[0]["job"] != "svc-web" || received[0]["result"] != "SUCCESS" || received[0]["number"] != float64(1) {
+		t.Errorf("webhook payload = %v", received[0])
+	}
+}
+
+func TestNotifyBuildFormats(t *testing.T) {
+	hook, payloads := newWebhook(t)
+	account := config.JenkinsConfig{Name: "ci", BaseApi: "http://jenkins/", Notifiers: []config.NotifierConfig{
+		{Type: config.NOTIFIER_SLACK, Url: hook.URL},
+		{Type: config.NOTIFIER_DINGTALK, Url: hook.URL},
+		{Type: config.NOTIFIER_FEISHU, Url: hook.URL},
+		{Type: config.NOTIFIER_TEAMS, Url: hook.URL},
+		{Type: "pager"},
+	}}
+	build := config.BuildSummary{JobName: "svc-api", Number: 7, Result: "FAILURE", Duration: 90000}
+	errs := util.NotifyBuild(account, build)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "unknown notifier type") {
+		t.Errorf("errors = %v", errs)
+	}
+
+	received := payloads()
+	if len(received) != 4 {
+		t.Fatalf("payloads = %v", received)
+	}
+	if text, _ := received[0]["text"].(string); !strings.Contains(text, "svc-api #7 FAILURE in 1m30s") || !strings.Contains(text, "http://jenkins/job/svc-api/7/") {
+		t.Errorf("slack payload = %v", received[0])
+	}
+	if received[1]["msgtype"] != "text" || received[2]["msg_type"] != "text" || received[3]["@type"] != "MessageCard" {
+		t.Errorf("dingtalk, feishu and teams payloads = %v", received[1:])
+	}
+}
+
+func TestWatcherFinishesTrackedBuilds(t *testing.T) {
+	server, account := setupTest(t)
+	hook, payloads := newWebhook(t)
+	account.Notifiers = []config.NotifierConfig{{Type: config.NOTIFIER_WEBHOOK, Url: hook.URL}}
+	writeTestAccounts(t, account)
+	build := server.AddBuild("svc-web", &jenkinstest.Build{Number: 1, Building: true})
+
+	if _, err := util.UpdateWatchState(func(state *config.WatchState) {
+		state.Builds = []config.WatchedBuild{
+			{Account: account.Name, Job: "svc-api", Number: 2, AddedAt: time.Now()},
+			{Account: account.Name, Job: "svc-web", Number: 1, AddedAt: time.Now()},
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		server.Update(func() { build.Building, build.Result = false, "UNSTABLE" })
+	}()
+	runWatcher(10 * time.Millisecond)
+
+	state, err := util.LoadWatchState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.Pid != 0 || len(util.PendingWatches(state)) != 0 {
+		t.Fatalf("state after the watcher stopped = %+v", state)
+	}
+	if state.Builds[0].Result != "FAILURE" || state.Builds[1].Result != "UNSTABLE" {
+		t.Errorf("results = %+v", state.Builds)
+	}
+	if received := payloads(); len(received) != 2 {
+		t.Errorf("payloads = %v", received)
+	}
+
+	output := runCommand(t, "watch", "list")
+	if !strings.Contains(output, "svc-api") || !strings.Contains(output, "UNSTABLE") {
+		t.Errorf("watch list output:\n%s", output)
+	}
+}
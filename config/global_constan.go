@@ -1,17 +1,62 @@
 package config
 
+import "time"
+
 const BASE_NAME = "jenkins-cli"
 const WORKSPACE_INFO = "workspace"
 const DEFAULT_ACCOUNT_NAME = "default"
+const DEFAULT_WORKSPACE_TTL = 24 * time.Hour
+const DEFAULT_RECENT_DEPTH = 3
+
+// Build parameter names understood by the interactive build flow.
+const PARAM_CHOICE = "pro"
+const PARAM_BRANCH = "tag"
 
 const BASE_CONFIG_DIR = "/.config/" + BASE_NAME + "/" + BASE_NAME + ".yaml"
 const WORKSPACE_INFO_DIR = "/.config/" + BASE_NAME + "/" + WORKSPACE_INFO + ".yaml"
+const WATCH_STATE_DIR = "/.config/" + BASE_NAME + "/watches.yaml"
+const WATCH_LOG_DIR = "/.config/" + BASE_NAME + "/watcher.log"
+const CACHE_DIR = "/.config/" + BASE_NAME + "/cache"
+
+// DEFAULT_CACHE_TTL is how long cached responses without a validator are served
+// without asking Jenkins.
+const DEFAULT_CACHE_TTL = 30 * time.Second
 
 type JenkinsConfig struct {
 	Name     string `yaml:"name"`
 	Username string `yaml:"username"`
 	Token    string `yaml:"token"`
 	BaseApi  string `yaml:"base_api"`
+	// WorkspaceTTL is how long synced workspace data stays fresh, e.g. "12h"; "0" disables background refresh.
+	WorkspaceTTL string `yaml:"workspace_ttl,omitempty"`
+	// Notifiers are told when a watched build finishes.
+	Notifiers []NotifierConfig `yaml:"notifiers,omitempty"`
+	// RefreshCache makes cached requests ask Jenkins and store the answer, for syncs
+	// that must not see what an earlier command cached.
+	RefreshCache bool `yaml:"-"`
+}
+
+// Notifier types.
+const (
+	NOTIFIER_BELL     = "bell"
+	NOTIFIER_DESKTOP  = "desktop"
+	NOTIFIER_COMMAND  = "command"
+	NOTIFIER_WEBHOOK  = "webhook"
+	NOTIFIER_SLACK    = "slack"
+	NOTIFIER_TEAMS    = "teams"
+	NOTIFIER_DINGTALK = "dingtalk"
+	NOTIFIER_FEISHU   = "feishu"
+)
+
+type NotifierConfig struct {
+	Type string `yaml:"type"`
+	// Command is run through the shell by the command notifier, with the build in
+	// JENKINS_* environment variables.
+	Command string `yaml:"command,omitempty"`
+	// Url is the endpoint of the webhook based notifiers.
+	Url string `yaml:"url,omitempty"`
+	// On restricts the notifier to these build results, e.g. [FAILURE, UNSTABLE].
+	On []string `yaml:"on,omitempty"`
 }
 
 type JenkinsConfigFile struct {
@@ -20,22 +65,54 @@ type JenkinsConfigFile struct {
 }
 
 type Workspace struct {
-	Views            []View   `yaml:"views"`
-	RecentViews      []string `yaml:"recent_views"`
-	LegacyRecentJobs []string `yaml:"recent_jobs,omitempty"`
+	Views            []View    `yaml:"views"`
+	RecentViews      []string  `yaml:"recent_views"`
+	LegacyRecentJobs []string  `yaml:"recent_jobs,omitempty"`
+	SyncedAt         time.Time `yaml:"synced_at,omitempty"`
+	RecentDepth      int       `yaml:"recent_depth,omitempty"`
+	Favorites        []string  `yaml:"favorites,omitempty"`
+	Aliases          []Alias   `yaml:"aliases,omitempty"`
+	Presets          []Preset  `yaml:"presets,omitempty"`
+}
+
+// Preset is a named job plus full parameter set. Values may contain templates such
+// as {{git.branch}}, {{env.USER}} or {{date}} that are expanded when the preset runs.
+type Preset struct {
+	Name   string            `yaml:"name"`
+	View   string            `yaml:"view,omitempty"`
+	Job    string            `yaml:"job"`
+	Params map[string]string `yaml:"params"`
+	Source string            `yaml:"source,omitempty"`
 }
 
+type Alias struct {
+	Name   string            `yaml:"name"`
+	View   string            `yaml:"view,omitempty"`
+	Job    string            `yaml:"job"`
+	Params map[string]string `yaml:"params,omitempty"`
+}
+
+// View is a node of the view tree. Nested views are named by their full path, with
+// the names of the enclosing views separated by "/". Views of folders are top level
+// views named after their folders, such as "platform » tools » backend".
 type View struct {
-	Name       string   `yaml:"name"`
-	Job        []Job    `yaml:"job"`
-	RecentJobs []string `yaml:"recent_jobs"`
+	Name       string    `yaml:"name"`
+	Job        []Job     `yaml:"job"`
+	RecentJobs []string  `yaml:"recent_jobs"`
+	SyncedAt   time.Time `yaml:"synced_at,omitempty"`
+	Views      []View    `yaml:"views,omitempty"`
 }
 
 type Job struct {
-	Name           string   `yaml:"name"`
-	JobParam       JobParam `yaml:"job_param"`
-	RecentChoices  []string `yaml:"recent_choices"`
-	RecentBranches []string `yaml:"recent_branches"`
+	Name           string    `yaml:"name"`
+	Marker         string    `yaml:"marker,omitempty"`
+	Color          string    `yaml:"color,omitempty"`
+	JobParam       JobParam  `yaml:"job_param"`
+	RecentChoices  []string  `yaml:"recent_choices"`
+	RecentBranches []string  `yaml:"recent_branches"`
+	SyncedAt       time.Time `yaml:"synced_at,omitempty"`
+	ScmUrls        []string  `yaml:"scm_urls,omitempty"`
+	ScmCheckedAt   time.Time `yaml:"scm_checked_at,omitempty"`
 }
 
 type JobParam struct {
@@ -45,6 +122,24 @@ type JobParam struct {
 	LegacyRecentBranches []string `yaml:"recent_branches,omitempty"`
 }
 
+type BranchItem struct {
+	Value string
+	Name  string
+}
+
+// ViewSummary is a view as listed by Jenkins, named like View.
+type ViewSummary struct {
+	Name  string
+	Jobs  []JobSummary
+	Views []ViewSummary
+}
+
+type JobSummary struct {
+	Name   string
+	Color  string
+	Marker string
+}
+
 type Queue struct {
 	Id           string
 	TaskName     string
@@ -53,6 +148,7 @@ type Queue struct {
 	Blocked      bool
 	Stuck        bool
 	InQueueSince string
+	Causes       []BuildCause
 }
 
 type Computer struct {
@@ -61,12 +157,84 @@ type Computer struct {
 }
 
 type BuildInfo struct {
-	QueueId         string `json:"queueId"`
-	BuildNumber     string `json:"buildNumber"`
-	Building        bool   `json:"building"`
-	Duration        int    `json:"duration"`
-	FullDisplayName string `json:"fullDisplayName"`
-	ChangeSets      []ChangeSet
+	QueueId           string `json:"queueId"`
+	BuildNumber       string `json:"buildNumber"`
+	Building          bool   `json:"building"`
+	Duration          int    `json:"duration"`
+	FullDisplayName   string `json:"fullDisplayName"`
+	Result            string `json:"result"`
+	Timestamp         int64  `json:"timestamp"`
+	EstimatedDuration int64  `json:"estimatedDuration"`
+	// BuiltOn is the agent the build ran on, empty for the built-in node.
+	BuiltOn     string `json:"builtOn"`
+	Description string `json:"description"`
+	Url         string `json:"url"`
+	Parameters  map[string]string
+	Causes      []BuildCause
+	ChangeSets  []ChangeSet
+}
+
+// Build cause types parsed from CauseAction.
+const (
+	CAUSE_UPSTREAM = "upstream"
+	CAUSE_USER     = "user"
+	CAUSE_SCM      = "scm"
+	CAUSE_TIMER    = "timer"
+	CAUSE_OTHER    = "other"
+)
+
+type BuildCause struct {
+	Type             string
+	ShortDescription string
+	// UpstreamProject is the full name of the upstream job, "platform/api" for the
+	// job api of the folder platform.
+	UpstreamProject string
+	UpstreamBuild   int
+	UserId          string
+	UserName        string
+}
+
+type BuildSummary struct {
+	JobName           string
+	Number            int
+	Result            string
+	Building          bool
+	Duration          int64
+	EstimatedDuration int64
+	Timestamp         int64
+	Causes            []BuildCause
+}
+
+// WatchState is shared by the watch commands and the background watcher, Pid is
+// the watcher process while it runs.
+type WatchState struct {
+	Pid    int            `yaml:"pid,omitempty"`
+	Builds []WatchedBuild `yaml:"builds"`
+}
+
+type WatchedBuild struct {
+	Account    string    `yaml:"account"`
+	Job        string    `yaml:"job"`
+	Number     int       `yaml:"number"`
+	Result     string    `yaml:"result,omitempty"`
+	Duration   int64     `yaml:"duration,omitempty"`
+	Error      string    `yaml:"error,omitempty"`
+	Failures   int       `yaml:"failures,omitempty"`
+	AddedAt    time.Time `yaml:"added_at"`
+	CheckedAt  time.Time `yaml:"checked_at,omitempty"`
+	FinishedAt time.Time `yaml:"finished_at,omitempty"`
+}
+
+// JobStatus is the dashboard line of a job. HealthScore is -1 when Jenkins has no
+// health report, builds that never happened are left zero.
+type JobStatus struct {
+	Name                string
+	Color               string
+	HealthScore         int
+	HealthDescription   string
+	LastBuild           BuildSummary
+	LastSuccessfulBuild BuildSummary
+	LastFailedBuild     BuildSummary
 }
 
 type ChangeSet struct {
@@ -74,6 +242,7 @@ type ChangeSet struct {
 	Timestamp      string
 	AuthorFullName string
 	Comment        string
+	AffectedPaths  []string
 }
 
 type PipelineConfig struct {
@@ -97,3 +266,28 @@ type Stage struct {
 	DurationMillis      int64  `json:"durationMillis"`
 	PauseDurationMillis int64  `json:"pauseDurationMillis"`
 }
+
+// Interaction is one request and response of a recorded cassette. Header values that
+// carry credentials are replaced by REDACTED_VALUE before it is written.
+type Interaction struct {
+	Method         string              `yaml:"method"`
+	Url            string              `yaml:"url"`
+	RequestHeader  map[string][]string `yaml:"request_header,omitempty"`
+	RequestBody    string              `yaml:"request_body,omitempty"`
+	Status         int                 `yaml:"status"`
+	ResponseHeader map[string][]string `yaml:"response_header,omitempty"`
+	ResponseBody   string              `yaml:"response_body"`
+}
+
+const REDACTED_VALUE = "REDACTED"
+
+// CacheEntry is a response kept by the on-disk cache. ETag and LastModified are the
+// validators Jenkins sent, if any, to revalidate the entry once StoredAt is too old.
+type CacheEntry struct {
+	Url          string              `yaml:"url"`
+	StoredAt     time.Time           `yaml:"stored_at"`
+	ETag         string              `yaml:"etag,omitempty"`
+	LastModified string              `yaml:"last_modified,omitempty"`
+	Header       map[string][]string `yaml:"header,omitempty"`
+	Body         string              `yaml:"body"`
+}
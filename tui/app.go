@@ -0,0 +1,458 @@
+// Package tui implements the full-screen monitoring interface of jenkins-cli.
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+const maxLogLines = 2000
+
+// JobItem is a job listed in the job pane, with the parameters offered when a build
+// is triggered from the interface.
+type JobItem struct {
+	Name         string
+	View         string
+	Color        string
+	Choices      []string
+	Branches     []string
+	RecentChoice string
+	RecentBranch string
+}
+
+type Options struct {
+	Account  config.JenkinsConfig
+	Jobs     []JobItem
+	Interval time.Duration
+	// OnBuild is called once a build was queued, e.g. to remember the parameters.
+	OnBuild func(job JobItem, params map[string]string)
+}
+
+type pane int
+
+const (
+	paneJobs pane = iota
+	paneRunning
+	paneQueue
+	paneCount
+)
+
+type buildRef struct {
+	Job    string
+	Number int
+}
+
+type picker struct {
+	title   string
+	options []string
+	index   int
+	onPick  func(value string)
+}
+
+type app struct {
+	opts   Options
+	screen *screen
+	events chan func(a *app)
+
+	focus        pane
+	jobIndex     int
+	runningIndex int
+	queueIndex   int
+
+	running     []config.Computer
+	queue       []config.Queue
+	updatedAt   time.Time
+	refreshing  bool
+	refreshErr  error
+	build       *buildRef
+	describe    config.WFDescribe
+	describeErr error
+	logText     string
+	logOffset   int
+	logScroll   int
+
+	message      string
+	messageStyle string
+	picker       *picker
+}
+
+// Run takes over the terminal until the user quits.
+func Run(opts Options) error {
+	if opts.Interval <= 0 {
+		opts.Interval = 3 * time.Second
+	}
+	s, err := openScreen()
+	if err != nil {
+		return err
+	}
+	defer s.close()
+
+	a := &app{opts: opts, screen: s, events: make(chan func(a *app), 16)}
+	keys := make(chan Key)
+	go readKeys(keys)
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	a.refresh()
+	for {
+		a.draw()
+		select {
+		case key, ok := <-keys:
+			if !ok || a.handleKey(key) {
+				return nil
+			}
+		case event := <-a.events:
+			event(a)
+		case <-ticker.C:
+			a.refresh()
+		}
+	}
+}
+
+// refresh loads the executors, the queue and the selected build in the background.
+// Only one refresh runs at a time; results are applied on the main loop.
+func (a *app) refresh() {
+	if a.refreshing {
+		return
+	}
+	a.refreshing = true
+	account := a.opts.Account
+	build := a.build
+	offset := a.logOffset
+	go func() {
+		running, runningErr := api.GetComputer(account)
+		queue, queueErr := api.GetQueue(account)
+		var describe config.WFDescribe
+		var describeErr, logErr error
+		logText, logSize := "", -1
+		if build != nil {
+			number := strconv.Itoa(build.Number)
+			describe, describeErr = api.GetWFDescribe(account, build.Job, number)
+			logText, _, logSize, logErr = api.GetTextLog(account, build.Job, number, &offset)
+		}
+		a.events <- func(a *app) {
+			a.refreshing = false
+			a.updatedAt = time.Now()
+			a.refreshErr = firstError(runningErr, queueErr, logErr)
+			if runningErr == nil {
+				a.running = running
+				a.runningIndex = clamp(a.runningIndex, len(a.running))
+			}
+			if queueErr == nil {
+				a.queue = queue
+				a.queueIndex = clamp(a.queueIndex, len(a.queue))
+			}
+			if a.build == nil && len(a.running) > 0 {
+				a.selectBuild(a.running[0].JobName, a.running[0].BuildNumber)
+				return
+			}
+			if a.build != nil && (build == nil || *build != *a.build) {
+				// The selection changed while loading, load the new build right away.
+				a.refresh()
+				return
+			}
+			if build == nil {
+				return
+			}
+			a.describe, a.describeErr = describe, describeErr
+			if logErr == nil {
+				a.appendLog(logText)
+				if logSize >= 0 {
+					a.logOffset = logSize
+				}
+			}
+		}
+	}()
+}
+
+func (a *app) selectBuild(jobName string, number int) {
+	next := &buildRef{Job: jobName, Number: number}
+	if a.build != nil && *a.build == *next {
+		return
+	}
+	a.build = next
+	a.describe, a.describeErr = config.WFDescribe{}, nil
+	a.logText, a.logOffset, a.logScroll = "", 0, 0
+	a.refresh()
+}
+
+func (a *app) appendLog(text string) {
+	if text == "" {
+		return
+	}
+	a.logText += strings.ReplaceAll(text, "\r\n", "\n")
+	if lines := strings.Count(a.logText, "\n"); lines > maxLogLines {
+		cut := 0
+		for range lines - maxLogLines {
+			cut += strings.IndexByte(a.logText[cut:], '\n') + 1
+		}
+		a.logText = a.logText[cut:]
+	}
+}
+
+// handleKey applies a key press and reports whether the interface should quit.
+func (a *app) handleKey(key Key) bool {
+	if key == KeyCtrlC {
+		return true
+	}
+	if a.picker != nil {
+		a.handlePickerKey(key)
+		return false
+	}
+	a.message = ""
+	switch key {
+	case 'q':
+		return true
+	case KeyTab:
+		a.focus = (a.focus + 1) % paneCount
+	case KeyUp, 'k':
+		a.move(-1)
+	case KeyDown, 'j':
+		a.move(1)
+	case KeyPageUp:
+		a.logScroll += max(a.screen.height/4, 1)
+	case KeyPageDown:
+		a.logScroll = max(a.logScroll-max(a.screen.height/4, 1), 0)
+	case KeyEnter:
+		a.open()
+	case 'b':
+		a.startBuild()
+	case 's':
+		a.stopBuild()
+	case 'c':
+		a.cancelQueueItem()
+	case 'r':
+		a.rebuild()
+	case 'R':
+		a.refresh()
+	}
+	return false
+}
+
+func (a *app) move(delta int) {
+	switch a.focus {
+	case paneJobs:
+		a.jobIndex = clamp(a.jobIndex+delta, len(a.opts.Jobs))
+	case paneRunning:
+		a.runningIndex = clamp(a.runningIndex+delta, len(a.running))
+		if len(a.running) > 0 {
+			a.selectBuild(a.running[a.runningIndex].JobName, a.running[a.runningIndex].BuildNumber)
+		}
+	case paneQueue:
+		a.queueIndex = clamp(a.queueIndex+delta, len(a.queue))
+	}
+}
+
+// open shows the last build of the selected job, or the selected running build.
+func (a *app) open() {
+	switch a.focus {
+	case paneJobs:
+		job, ok := a.selectedJob()
+		if !ok {
+			return
+		}
+		account := a.opts.Account
+		go func() {
+			build, err := api.GetBuildSummary(account, job.Name, "lastBuild")
+			a.events <- func(a *app) {
+				if err != nil || build.Number == 0 {
+					a.setError("No build found for %s", job.Name)
+					return
+				}
+				a.selectBuild(job.Name, build.Number)
+			}
+		}()
+	case paneRunning:
+		if len(a.running) > 0 {
+			a.selectBuild(a.running[a.runningIndex].JobName, a.running[a.runningIndex].BuildNumber)
+		}
+	}
+}
+
+// startBuild asks for the choice and branch parameters the job declares, then
+// triggers it.
+func (a *app) startBuild() {
+	job, ok := a.selectedJob()
+	if !ok || a.focus != paneJobs {
+		a.setError("Select a job in the job pane to build it")
+		return
+	}
+	params := make(map[string]string)
+	pickBranch := func() {
+		if len(job.Branches) == 0 {
+			a.trigger(job, params)
+			return
+		}
+		a.openPicker("Branch for "+job.Name, job.Branches, job.RecentBranch, func(value string) {
+			params[config.PARAM_BRANCH] = value
+			a.trigger(job, params)
+		})
+	}
+	if len(job.Choices) == 0 {
+		pickBranch()
+		return
+	}
+	a.openPicker("Choice for "+job.Name, job.Choices, job.RecentChoice, func(value string) {
+		params[config.PARAM_CHOICE] = value
+		pickBranch()
+	})
+}
+
+func (a *app) trigger(job JobItem, params map[string]string) {
+	account := a.opts.Account
+	a.setInfo("Queueing %s...", job.Name)
+	go func() {
+		queueId, err := api.BuildWithParams(account, job.Name, params)
+		a.events <- func(a *app) {
+			if err != nil {
+				a.setError("Build of %s failed: %v", job.Name, err)
+				return
+			}
+			a.setInfo("%s queued (queue id %s)", job.Name, queueId)
+			if a.opts.OnBuild != nil {
+				a.opts.OnBuild(job, params)
+			}
+			a.refresh()
+		}
+	}()
+}
+
+func (a *app) stopBuild() {
+	target := a.build
+	if a.focus == paneRunning && len(a.running) > 0 {
+		target = &buildRef{Job: a.running[a.runningIndex].JobName, Number: a.running[a.runningIndex].BuildNumber}
+	}
+	if target == nil {
+		a.setError("No build selected")
+		return
+	}
+	label := fmt.Sprintf("%s #%d", target.Job, target.Number)
+	a.confirm("Stop "+label+"?", func() {
+		account := a.opts.Account
+		go func() {
+			_, err := api.Stop(account, target.Job, strconv.Itoa(target.Number))
+			a.events <- func(a *app) {
+				if err != nil {
+					a.setError("Stopping %s failed: %v", label, err)
+					return
+				}
+				a.setInfo("%s stopped", label)
+				a.refresh()
+			}
+		}()
+	})
+}
+
+func (a *app) cancelQueueItem() {
+	if a.focus != paneQueue || len(a.queue) == 0 {
+		a.setError("Select a queue item in the queue pane to cancel it")
+		return
+	}
+	item := a.queue[a.queueIndex]
+	a.confirm("Cancel queued "+item.TaskName+"?", func() {
+		account := a.opts.Account
+		go func() {
+			_, err := api.CancelItem(account, item.Id)
+			a.events <- func(a *app) {
+				if err != nil {
+					a.setError("Cancelling %s failed: %v", item.TaskName, err)
+					return
+				}
+				a.setInfo("%s removed from the queue", item.TaskName)
+				a.refresh()
+			}
+		}()
+	})
+}
+
+// rebuild queues the selected build again with the same parameters.
+func (a *app) rebuild() {
+	if a.build == nil {
+		a.setError("No build selected")
+		return
+	}
+	build := *a.build
+	account := a.opts.Account
+	a.setInfo("Rebuilding %s #%d...", build.Job, build.Number)
+	go func() {
+		params, err := api.GetBuildParameters(account, build.Job, strconv.Itoa(build.Number))
+		queueId := ""
+		if err == nil {
+			queueId, err = api.BuildWithParams(account, build.Job, params)
+		}
+		a.events <- func(a *app) {
+			if err != nil {
+				a.setError("Rebuild of %s #%d failed: %v", build.Job, build.Number, err)
+				return
+			}
+			a.setInfo("%s #%d rebuilt (queue id %s)", build.Job, build.Number, queueId)
+			a.refresh()
+		}
+	}()
+}
+
+func (a *app) openPicker(title string, options []string, current string, onPick func(value string)) {
+	index := 0
+	for i, option := range options {
+		if option == current {
+			index = i
+		}
+	}
+	a.picker = &picker{title: title, options: options, index: index, onPick: onPick}
+}
+
+func (a *app) confirm(question string, onYes func()) {
+	a.openPicker(question, []string{"No", "Yes"}, "No", func(value string) {
+		if value == "Yes" {
+			onYes()
+		}
+	})
+}
+
+func (a *app) handlePickerKey(key Key) {
+	p := a.picker
+	switch key {
+	case KeyUp, 'k':
+		p.index = clamp(p.index-1, len(p.options))
+	case KeyDown, 'j':
+		p.index = clamp(p.index+1, len(p.options))
+	case KeyEnter:
+		a.picker = nil
+		p.onPick(p.options[p.index])
+	case KeyEscape, 'q':
+		a.picker = nil
+	}
+}
+
+func (a *app) selectedJob() (JobItem, bool) {
+	if len(a.opts.Jobs) == 0 {
+		return JobItem{}, false
+	}
+	return a.opts.Jobs[a.jobIndex], true
+}
+
+func (a *app) setInfo(format string, args ...any) {
+	a.message, a.messageStyle = fmt.Sprintf(format, args...), styleGreen
+}
+
+func (a *app) setError(format string, args ...any) {
+	a.message, a.messageStyle = fmt.Sprintf(format, args...), styleRed
+}
+
+func clamp(index, count int) int {
+	return max(min(index, count-1), 0)
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/util"
+)
+
+const helpText = "Tab pane  ↑↓ move  Enter open  b build  s stop  c cancel  r rebuild  R refresh  PgUp/PgDn log  q quit"
+
+func (a *app) draw() {
+	s := a.screen
+	s.resize()
+	if s.width < 40 || s.height < 12 {
+		s.text(0, 0, s.width, "Terminal too small", styleYellow)
+		s.flush()
+		return
+	}
+
+	header := fmt.Sprintf(" jenkins-cli  %s  %s", a.opts.Account.Name, a.opts.Account.BaseApi)
+	if !a.updatedAt.IsZero() {
+		header += "  updated " + a.updatedAt.Format("15:04:05")
+	}
+	s.fill(0, 0, s.width, styleSelect)
+	s.text(0, 0, s.width, header, styleSelect)
+
+	bodyTop, bodyHeight := 1, s.height-2
+	leftWidth := min(max(s.width/3, 24), 50)
+	rightX, rightWidth := leftWidth, s.width-leftWidth
+	runningHeight := max(bodyHeight/5, 4)
+	queueHeight := max(bodyHeight/6, 4)
+	stagesHeight := max(bodyHeight/4, 4)
+	logHeight := bodyHeight - runningHeight - queueHeight - stagesHeight
+
+	a.drawJobs(0, bodyTop, leftWidth, bodyHeight)
+	y := bodyTop
+	a.drawRunning(rightX, y, rightWidth, runningHeight)
+	y += runningHeight
+	a.drawQueue(rightX, y, rightWidth, queueHeight)
+	y += queueHeight
+	a.drawStages(rightX, y, rightWidth, stagesHeight)
+	y += stagesHeight
+	a.drawLog(rightX, y, rightWidth, logHeight)
+
+	footer, footerStyle := helpText, styleDim
+	switch {
+	case a.message != "":
+		footer, footerStyle = a.message, a.messageStyle
+	case a.refreshErr != nil:
+		footer, footerStyle = "Refresh failed: "+a.refreshErr.Error(), styleRed
+	}
+	s.text(1, s.height-1, s.width-2, footer, footerStyle)
+
+	if a.picker != nil {
+		a.drawPicker()
+	}
+	s.flush()
+}
+
+func (a *app) drawJobs(x, y, width, height int) {
+	s := a.screen
+	s.box(x, y, width, height, fmt.Sprintf("Jobs (%d)", len(a.opts.Jobs)), a.focus == paneJobs)
+	visible := height - 2
+	start := listWindow(a.jobIndex, len(a.opts.Jobs), visible)
+	for row := 0; row < visible && start+row < len(a.opts.Jobs); row++ {
+		index := start + row
+		job := a.opts.Jobs[index]
+		lineY := y + 1 + row
+		s.text(x+2, lineY, 1, "●", statusStyle(util.JobColorStatus(job.Color)))
+		s.text(x+4, lineY, width-5, job.Name, styleNone)
+		if view := " " + job.View; len(job.Name)+len(view) < width-6 {
+			s.text(x+4+len([]rune(job.Name)), lineY, width-5-len([]rune(job.Name)), view, styleDim)
+		}
+		if index == a.jobIndex && a.focus == paneJobs {
+			s.fill(x+1, lineY, width-2, styleSelect)
+		}
+	}
+}
+
+func (a *app) drawRunning(x, y, width, height int) {
+	s := a.screen
+	s.box(x, y, width, height, fmt.Sprintf("Running (%d)", len(a.running)), a.focus == paneRunning)
+	visible := height - 2
+	if len(a.running) == 0 {
+		s.text(x+2, y+1, width-4, "No running builds", styleDim)
+		return
+	}
+	start := listWindow(a.runningIndex, len(a.running), visible)
+	for row := 0; row < visible && start+row < len(a.running); row++ {
+		index := start + row
+		build := a.running[index]
+		lineY := y + 1 + row
+		s.text(x+2, lineY, width-4, fmt.Sprintf("%s #%d", build.JobName, build.BuildNumber), styleCyan)
+		if a.build != nil && a.build.Job == build.JobName && a.build.Number == build.BuildNumber {
+			s.text(x+width-4, lineY, 1, "◀", styleYellow)
+		}
+		if index == a.runningIndex && a.focus == paneRunning {
+			s.fill(x+1, lineY, width-2, styleSelect)
+		}
+	}
+}
+
+func (a *app) drawQueue(x, y, width, height int) {
+	s := a.screen
+	s.box(x, y, width, height, fmt.Sprintf("Queue (%d)", len(a.queue)), a.focus == paneQueue)
+	visible := height - 2
+	if len(a.queue) == 0 {
+		s.text(x+2, y+1, width-4, "Queue is empty", styleDim)
+		return
+	}
+	start := listWindow(a.queueIndex, len(a.queue), visible)
+	for row := 0; row < visible && start+row < len(a.queue); row++ {
+		index := start + row
+		item := a.queue[index]
+		lineY := y + 1 + row
+		style := styleNone
+		if item.Stuck || item.Blocked {
+			style = styleYellow
+		}
+		s.text(x+2, lineY, width-4, item.TaskName+"  "+item.Why, style)
+		if index == a.queueIndex && a.focus == paneQueue {
+			s.fill(x+1, lineY, width-2, styleSelect)
+		}
+	}
+}
+
+func (a *app) drawStages(x, y, width, height int) {
+	s := a.screen
+	title := "Stages"
+	if a.build != nil {
+		title = fmt.Sprintf("Stages  %s #%d", a.build.Job, a.build.Number)
+		if a.describe.Status != "" {
+			title += "  " + a.describe.Status
+		}
+	}
+	s.box(x, y, width, height, title, false)
+	switch {
+	case a.build == nil:
+		s.text(x+2, y+1, width-4, "No build selected, press Enter on a job or running build", styleDim)
+		return
+	case a.describeErr != nil:
+		s.text(x+2, y+1, width-4, "No pipeline stages for this build", styleDim)
+		return
+	}
+	visible := height - 2
+	stages := a.describe.Stages
+	// Keep the latest stages in view while a long pipeline runs.
+	start := max(len(stages)-visible, 0)
+	for row := 0; row < visible && start+row < len(stages); row++ {
+		stage := stages[start+row]
+		lineY := y + 1 + row
+		duration := (time.Duration(stage.DurationMillis) * time.Millisecond).Round(time.Second)
+		s.text(x+2, lineY, 1, "●", statusStyle(stage.Status))
+		s.text(x+4, lineY, width-6, fmt.Sprintf("%-30s %-12s %s", stage.Name, stage.Status, duration), styleNone)
+	}
+}
+
+func (a *app) drawLog(x, y, width, height int) {
+	s := a.screen
+	title := "Log"
+	if a.logScroll > 0 {
+		title = fmt.Sprintf("Log  -%d lines", a.logScroll)
+	}
+	s.box(x, y, width, height, title, false)
+	visible := height - 2
+	lines := strings.Split(strings.TrimSuffix(a.logText, "\n"), "\n")
+	a.logScroll = min(a.logScroll, max(len(lines)-visible, 0))
+	end := len(lines) - a.logScroll
+	start := max(end-visible, 0)
+	for row, line := range lines[start:end] {
+		s.text(x+1, y+1+row, width-2, strings.ReplaceAll(line, "\t", "    "), styleNone)
+	}
+}
+
+func (a *app) drawPicker() {
+	s := a.screen
+	p := a.picker
+	width := len([]rune(p.title)) + 6
+	for _, option := range p.options {
+		width = max(width, len([]rune(option))+6)
+	}
+	width = min(width, s.width-4)
+	height := min(len(p.options)+2, s.height-4)
+	x, y := (s.width-width)/2, (s.height-height)/2
+	for row := y; row < y+height; row++ {
+		s.text(x, row, width, strings.Repeat(" ", width), styleNone)
+	}
+	s.box(x, y, width, height, p.title, true)
+	visible := height - 2
+	start := listWindow(p.index, len(p.options), visible)
+	for row := 0; row < visible && start+row < len(p.options); row++ {
+		index := start + row
+		s.text(x+2, y+1+row, width-4, p.options[index], styleNone)
+		if index == p.index {
+			s.fill(x+1, y+1+row, width-2, styleSelect)
+		}
+	}
+}
+
+// listWindow returns the first row to draw so that the selected row stays visible.
+func listWindow(selected, count, visible int) int {
+	if visible <= 0 || count <= visible {
+		return 0
+	}
+	return min(max(selected-visible/2, 0), count-visible)
+}
+
+func statusStyle(status string) string {
+	switch status {
+	case "SUCCESS":
+		return styleGreen
+	case "FAILURE", "FAILED":
+		return styleRed
+	case "UNSTABLE", "ABORTED", "PAUSED_PENDING_INPUT":
+		return styleYellow
+	case "BUILDING", "IN_PROGRESS":
+		return styleCyan
+	}
+	return styleDim
+}
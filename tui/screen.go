@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Key is a decoded key press. Printable keys are reported as their rune.
+type Key int
+
+const (
+	KeyNone Key = -(iota + 1)
+	KeyUp
+	KeyDown
+	KeyPageUp
+	KeyPageDown
+	KeyEnter
+	KeyTab
+	KeyEscape
+	KeyCtrlC
+)
+
+const (
+	styleNone   = ""
+	styleBold   = "\033[1m"
+	styleDim    = "\033[90m"
+	styleRed    = "\033[31m"
+	styleGreen  = "\033[32m"
+	styleYellow = "\033[33m"
+	styleCyan   = "\033[36m"
+	styleSelect = "\033[7m"
+	styleReset  = "\033[0m"
+)
+
+type cell struct {
+	r     rune
+	style string
+}
+
+// screen owns the terminal while the interface runs: raw input on stdin and a frame
+// buffer that is flushed to the alternate screen in one write.
+type screen struct {
+	fd       int
+	oldState *term.State
+	out      *bufio.Writer
+	width    int
+	height   int
+	cells    [][]cell
+}
+
+func openScreen() (*screen, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("the interface needs an interactive terminal")
+	}
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, err
+	}
+	s := &screen{fd: fd, oldState: oldState, out: bufio.NewWriter(os.Stdout)}
+	// Alternate screen, hidden cursor.
+	s.out.WriteString("\033[?1049h\033[?25l")
+	s.out.Flush()
+	s.resize()
+	return s, nil
+}
+
+func (s *screen) close() {
+	s.out.WriteString(styleReset + "\033[?25h\033[?1049l")
+	s.out.Flush()
+	term.Restore(s.fd, s.oldState)
+}
+
+// resize picks up the current terminal size and clears the frame buffer.
+func (s *screen) resize() {
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 80, 24
+	}
+	s.width, s.height = width, height
+	s.cells = make([][]cell, height)
+	for y := range s.cells {
+		s.cells[y] = make([]cell, width)
+		for x := range s.cells[y] {
+			s.cells[y][x] = cell{r: ' '}
+		}
+	}
+}
+
+// text writes str at x, y, cut at maxWidth columns. Control characters are dropped.
+func (s *screen) text(x, y, maxWidth int, str, style string) {
+	if y < 0 || y >= s.height {
+		return
+	}
+	for _, r := range str {
+		if maxWidth <= 0 || x >= s.width {
+			return
+		}
+		if r < 0x20 || r == 0x7f {
+			continue
+		}
+		if x >= 0 {
+			s.cells[y][x] = cell{r: r, style: style}
+		}
+		x++
+		maxWidth--
+	}
+}
+
+// fill paints a horizontal run of cells with a style, used for the selection bar.
+func (s *screen) fill(x, y, width int, style string) {
+	if y < 0 || y >= s.height {
+		return
+	}
+	for ; width > 0 && x < s.width; x, width = x+1, width-1 {
+		if x >= 0 {
+			s.cells[y][x].style = style
+		}
+	}
+}
+
+func (s *screen) box(x, y, width, height int, title string, focused bool) {
+	if width < 2 || height < 2 {
+		return
+	}
+	style := styleDim
+	if focused {
+		style = styleCyan
+	}
+	horizontal := strings.Repeat("─", width-2)
+	s.text(x, y, width, "┌"+horizontal+"┐", style)
+	s.text(x, y+height-1, width, "└"+horizontal+"┘", style)
+	for row := y + 1; row < y+height-1; row++ {
+		s.text(x, row, 1, "│", style)
+		s.text(x+width-1, row, 1, "│", style)
+	}
+	if title != "" {
+		titleStyle := styleBold
+		if focused {
+			titleStyle = styleBold + styleCyan
+		}
+		s.text(x+2, y, width-4, " "+title+" ", titleStyle)
+	}
+}
+
+func (s *screen) flush() {
+	s.out.WriteString("\033[H")
+	current := ""
+	for y, row := range s.cells {
+		for _, c := range row {
+			if c.style != current {
+				s.out.WriteString(styleReset + c.style)
+				current = c.style
+			}
+			s.out.WriteRune(c.r)
+		}
+		if y < len(s.cells)-1 {
+			s.out.WriteString("\r\n")
+		}
+	}
+	s.out.WriteString(styleReset)
+	s.out.Flush()
+}
+
+// readKeys decodes stdin into keys until it fails.
+func readKeys(keys chan<- Key) {
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		r, _, err := reader.ReadRune()
+		if err != nil {
+			close(keys)
+			return
+		}
+		switch r {
+		case 3:
+			keys <- KeyCtrlC
+		case '\r', '\n':
+			keys <- KeyEnter
+		case '\t':
+			keys <- KeyTab
+		case 0x1b:
+			keys <- readEscape(reader)
+		default:
+			keys <- Key(r)
+		}
+	}
+}
+
+// readEscape decodes the CSI sequences of the arrow and page keys. A lone escape is
+// reported when no sequence follows right away.
+func readEscape(reader *bufio.Reader) Key {
+	if reader.Buffered() == 0 {
+		return KeyEscape
+	}
+	next, _ := reader.ReadByte()
+	if next != '[' && next != 'O' {
+		return KeyEscape
+	}
+	code, _ := reader.ReadByte()
+	switch code {
+	case 'A':
+		return KeyUp
+	case 'B':
+		return KeyDown
+	case '5', '6':
+		reader.ReadByte() // trailing '~'
+		if code == '5' {
+			return KeyPageUp
+		}
+		return KeyPageDown
+	}
+	return KeyNone
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/lemonsoul/jenkins-cli/config"
 	"gopkg.in/yaml.v3"
@@ -15,6 +16,10 @@ func GetConfigFilePath() string {
 	return os.Getenv("HOME") + config.BASE_CONFIG_DIR
 }
 
+func GetCacheDirPath() string {
+	return os.Getenv("HOME") + config.CACHE_DIR
+}
+
 func GetWorkspaceFilePathByName(accountName string) string {
 	if strings.TrimSpace(accountName) == "" || accountName == config.DEFAULT_ACCOUNT_NAME {
 		return os.Getenv("HOME") + config.WORKSPACE_INFO_DIR
@@ -61,14 +66,14 @@ func PickAccount(accountName string) (config.JenkinsConfig, error) {
 		if !ok {
 			return config.JenkinsConfig{}, fmt.Errorf("account not found: %s", accountName)
 		}
-		cfgFile.RecentAccounts = UpdateRecent(cfgFile.RecentAccounts, accountName, 3)
+		cfgFile.RecentAccounts = UpdateRecent(cfgFile.RecentAccounts, accountName, config.DEFAULT_RECENT_DEPTH)
 		_ = writeConfigFile(GetConfigFilePath(), cfgFile)
 		return account, nil
 	}
 
 	if len(accounts) == 1 {
 		for name, account := range accounts {
-			cfgFile.RecentAccounts = UpdateRecent(cfgFile.RecentAccounts, name, 3)
+			cfgFile.RecentAccounts = UpdateRecent(cfgFile.RecentAccounts, name, config.DEFAULT_RECENT_DEPTH)
 			_ = writeConfigFile(GetConfigFilePath(), cfgFile)
 			return account, nil
 		}
@@ -79,7 +84,7 @@ func PickAccount(accountName string) (config.JenkinsConfig, error) {
 		accountNames = append(accountNames, name)
 	}
 	sort.Strings(accountNames)
-	recent := FilterRecent(cfgFile.RecentAccounts, BuildAllowSet(accountNames), 3)
+	recent := FilterRecent(cfgFile.RecentAccounts, BuildAllowSet(accountNames), config.DEFAULT_RECENT_DEPTH)
 	selected := StrUISelectWithRecent("Select Account", accountNames, recent)
 	if strings.TrimSpace(selected) == "" {
 		return config.JenkinsConfig{}, fmt.Errorf("no account selected")
@@ -89,7 +94,7 @@ func PickAccount(accountName string) (config.JenkinsConfig, error) {
 	if !ok {
 		return config.JenkinsConfig{}, fmt.Errorf("selected account not found: %s", selected)
 	}
-	cfgFile.RecentAccounts = UpdateRecent(cfgFile.RecentAccounts, selected, 3)
+	cfgFile.RecentAccounts = UpdateRecent(cfgFile.RecentAccounts, selected, config.DEFAULT_RECENT_DEPTH)
 	_ = writeConfigFile(GetConfigFilePath(), cfgFile)
 	return account, nil
 }
@@ -194,17 +199,17 @@ func ensureWorkspaceRecent(cfg *config.Workspace) bool {
 		cfg.RecentViews = make([]string, 0)
 		updated = true
 	}
-	for viewIndex := range cfg.Views {
-		if cfg.Views[viewIndex].RecentJobs == nil {
-			cfg.Views[viewIndex].RecentJobs = make([]string, 0)
+	for _, view := range AllViews(cfg) {
+		if view.RecentJobs == nil {
+			view.RecentJobs = make([]string, 0)
 			updated = true
 		}
-		if len(cfg.LegacyRecentJobs) > 0 && len(cfg.Views[viewIndex].RecentJobs) == 0 {
-			cfg.Views[viewIndex].RecentJobs = append([]string(nil), cfg.LegacyRecentJobs...)
+		if len(cfg.LegacyRecentJobs) > 0 && len(view.RecentJobs) == 0 {
+			view.RecentJobs = append([]string(nil), cfg.LegacyRecentJobs...)
 			updated = true
 		}
-		for jobIndex := range cfg.Views[viewIndex].Job {
-			job := &cfg.Views[viewIndex].Job[jobIndex]
+		for jobIndex := range view.Job {
+			job := &view.Job[jobIndex]
 			if job.RecentChoices == nil {
 				job.RecentChoices = make([]string, 0)
 				updated = true
@@ -349,3 +354,25 @@ func sanitizeAccountName(name string) string {
 		}
 	}, name)
 }
+
+// GetWorkspaceTTL returns how long synced workspace data of an account stays fresh.
+// A zero duration disables staleness checks.
+func GetWorkspaceTTL(account config.JenkinsConfig) time.Duration {
+	value := strings.TrimSpace(account.WorkspaceTTL)
+	if value == "" {
+		return config.DEFAULT_WORKSPACE_TTL
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl < 0 {
+		return config.DEFAULT_WORKSPACE_TTL
+	}
+	return ttl
+}
+
+// GetRecentDepth returns how many recent selections are kept per level of the workspace.
+func GetRecentDepth(cfg config.Workspace) int {
+	if cfg.RecentDepth > 0 {
+		return cfg.RecentDepth
+	}
+	return config.DEFAULT_RECENT_DEPTH
+}
@@ -0,0 +1,191 @@
+package util
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+type DiffOp int
+
+const (
+	DiffEqual DiffOp = iota
+	DiffDelete
+	DiffInsert
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+const diffContext = 3
+
+// DiffLines computes a line diff of before and after from their longest common
+// subsequence. Common leading and trailing lines are matched directly and the rest
+// is diffed with Hirschberg's algorithm, so memory stays linear in the number of
+// lines however large the configurations are.
+func DiffLines(before, after string) []DiffLine {
+	a := splitLines(before)
+	b := splitLines(after)
+	lines := make([]DiffLine, 0, len(a)+len(b))
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		lines = append(lines, DiffLine{Op: DiffEqual, Text: a[prefix]})
+		prefix++
+	}
+	a, b = a[prefix:], b[prefix:]
+	suffix := 0
+	for suffix < len(a) && suffix < len(b) && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	lines = appendDiff(lines, a[:len(a)-suffix], b[:len(b)-suffix])
+	for _, line := range a[len(a)-suffix:] {
+		lines = append(lines, DiffLine{Op: DiffEqual, Text: line})
+	}
+	return lines
+}
+
+// appendDiff appends the diff of a and b to lines. It splits a in halves and b
+// where the longest common subsequences of both halves add up to the longest one.
+func appendDiff(lines []DiffLine, a, b []string) []DiffLine {
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			lines = append(lines, DiffLine{Op: DiffInsert, Text: line})
+		}
+		return lines
+	case len(b) == 0:
+		for _, line := range a {
+			lines = append(lines, DiffLine{Op: DiffDelete, Text: line})
+		}
+		return lines
+	case len(a) == 1:
+		index := slices.Index(b, a[0])
+		if index < 0 {
+			lines = append(lines, DiffLine{Op: DiffDelete, Text: a[0]})
+			return appendDiff(lines, nil, b)
+		}
+		lines = appendDiff(lines, nil, b[:index])
+		lines = append(lines, DiffLine{Op: DiffEqual, Text: a[0]})
+		return appendDiff(lines, nil, b[index+1:])
+	}
+	mid := len(a) / 2
+	forward := lcsLengths(a[:mid], b)
+	backward := lcsLengths(reversed(a[mid:]), reversed(b))
+	split, best := 0, -1
+	for j := range len(b) + 1 {
+		if length := forward[j] + backward[len(b)-j]; length > best {
+			split, best = j, length
+		}
+	}
+	lines = appendDiff(lines, a[:mid], b[:split])
+	return appendDiff(lines, a[mid:], b[split:])
+}
+
+// lcsLengths returns the lengths of the longest common subsequences of a and every
+// prefix of b, keeping only two rows of the table.
+func lcsLengths(a, b []string) []int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				current[j+1] = previous[j] + 1
+			} else {
+				current[j+1] = max(previous[j+1], current[j])
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous
+}
+
+func reversed(lines []string) []string {
+	copied := slices.Clone(lines)
+	slices.Reverse(copied)
+	return copied
+}
+
+// HasChanges reports whether a diff contains any inserted or deleted line.
+func HasChanges(lines []DiffLine) bool {
+	for _, line := range lines {
+		if line.Op != DiffEqual {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatDiff renders a diff in unified format with colored hunks, keeping a few
+// lines of context around each change.
+func FormatDiff(lines []DiffLine, fromName, toName string) string {
+	var builder strings.Builder
+	builder.WriteString(color.New(color.Bold).Sprintf("--- %s\n+++ %s\n", fromName, toName))
+
+	oldLine, newLine := make([]int, len(lines)), make([]int, len(lines))
+	oldNo, newNo := 1, 1
+	for index, line := range lines {
+		oldLine[index], newLine[index] = oldNo, newNo
+		if line.Op != DiffInsert {
+			oldNo++
+		}
+		if line.Op != DiffDelete {
+			newNo++
+		}
+	}
+
+	for start := 0; start < len(lines); {
+		if lines[start].Op == DiffEqual {
+			start++
+			continue
+		}
+		// Grow the hunk while the next change is within two context windows.
+		end := start
+		for next := start; next < len(lines); next++ {
+			if lines[next].Op != DiffEqual {
+				end = next
+			} else if next-end > 2*diffContext {
+				break
+			}
+		}
+		from := max(start-diffContext, 0)
+		to := min(end+diffContext+1, len(lines))
+
+		oldCount, newCount := 0, 0
+		for _, line := range lines[from:to] {
+			if line.Op != DiffInsert {
+				oldCount++
+			}
+			if line.Op != DiffDelete {
+				newCount++
+			}
+		}
+		builder.WriteString(color.CyanString("@@ -%d,%d +%d,%d @@", oldLine[from], oldCount, newLine[from], newCount))
+		builder.WriteString("\n")
+		for _, line := range lines[from:to] {
+			switch line.Op {
+			case DiffDelete:
+				builder.WriteString(color.RedString("-%s", line.Text))
+			case DiffInsert:
+				builder.WriteString(color.GreenString("+%s", line.Text))
+			default:
+				fmt.Fprintf(&builder, " %s", line.Text)
+			}
+			builder.WriteString("\n")
+		}
+		start = to
+	}
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
@@ -0,0 +1,106 @@
+package util
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestDiffLinesEmptyAndIdentical(t *testing.T) {
+	if lines := DiffLines("", ""); len(lines) != 0 || HasChanges(lines) {
+		t.Errorf("diff of empty texts = %+v", lines)
+	}
+	text := "<project>\r\n  <description>api</description>\r\n</project>\r\n"
+	lines := DiffLines(text, strings.ReplaceAll(text, "\r\n", "\n"))
+	if len(lines) != 3 || HasChanges(lines) {
+		t.Errorf("diff of identical texts = %+v", lines)
+	}
+	if lines := DiffLines("", "a\nb\n"); !reflect.DeepEqual(lines, []DiffLine{{DiffInsert, "a"}, {DiffInsert, "b"}}) {
+		t.Errorf("diff from an empty text = %+v", lines)
+	}
+	if lines := DiffLines("a\nb", ""); !reflect.DeepEqual(lines, []DiffLine{{DiffDelete, "a"}, {DiffDelete, "b"}}) {
+		t.Errorf("diff to an empty text = %+v", lines)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	lines := DiffLines("a\nb\nc\nd\n", "a\nc\nx\nd\n")
+	expected := []DiffLine{{DiffEqual, "a"}, {DiffDelete, "b"}, {DiffEqual, "c"}, {DiffInsert, "x"}, {DiffEqual, "d"}}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("DiffLines = %+v, want %+v", lines, expected)
+	}
+}
+
+// TestDiffLinesMinimal checks on random texts that the diff turns before into after
+// and keeps as many lines as the longest common subsequence.
+func TestDiffLinesMinimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomText := func() []string {
+		lines := make([]string, random.Intn(30))
+		for index := range lines {
+			lines[index] = strconv.Itoa(random.Intn(5))
+		}
+		return lines
+	}
+	for range 200 {
+		a, b := randomText(), randomText()
+		lines := DiffLines(strings.Join(a, "\n"), strings.Join(b, "\n"))
+		old, new, equal := make([]string, 0), make([]string, 0), 0
+		for _, line := range lines {
+			if line.Op != DiffInsert {
+				old = append(old, line.Text)
+			}
+			if line.Op != DiffDelete {
+				new = append(new, line.Text)
+			}
+			if line.Op == DiffEqual {
+				equal++
+			}
+		}
+		if !reflect.DeepEqual(old, a) || !reflect.DeepEqual(new, b) {
+			t.Fatalf("diff of %v and %v does not rebuild them: %+v", a, b, lines)
+		}
+		if length := lcsLengths(a, b)[len(b)]; equal != length {
+			t.Fatalf("diff of %v and %v keeps %d lines, want %d", a, b, equal, length)
+		}
+	}
+}
+
+// TestDiffLinesLargeInput diffs texts whose full LCS table would take 200 MB.
+func TestDiffLinesLargeInput(t *testing.T) {
+	before := make([]string, 5000)
+	for index := range before {
+		before[index] = "<line>" + strconv.Itoa(index) + "</line>"
+	}
+	after := append([]string{"<header/>"}, before...)
+	after[2500] = "<changed/>"
+	lines := DiffLines(strings.Join(before, "\n"), strings.Join(after, "\n"))
+	changed := 0
+	for _, line := range lines {
+		if line.Op != DiffEqual {
+			changed++
+		}
+	}
+	if changed != 3 {
+		t.Errorf("large diff has %d changed lines, want 3", changed)
+	}
+}
+
+func TestFormatDiff(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	t.Cleanup(func() { color.NoColor = noColor })
+
+	lines := DiffLines("1\n2\n3\n4\n5\n6\n7\n8\n9\n", "1\n2\n3\n4\nfive\n6\n7\n8\n9\n")
+	expected := "--- remote\n+++ local\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n"
+	if got := FormatDiff(lines, "remote", "local"); got != expected {
+		t.Errorf("FormatDiff =\n%s\nwant\n%s", got, expected)
+	}
+	if got := FormatDiff(DiffLines("same", "same"), "remote", "local"); got != "--- remote\n+++ local\n" {
+		t.Errorf("FormatDiff without changes = %q", got)
+	}
+}
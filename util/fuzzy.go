@@ -0,0 +1,144 @@
+package util
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Fuzzy match scores, best first. Within a tier shorter and earlier matches win.
+const (
+	scoreExact        = 1000
+	scorePrefix       = 900
+	scoreAcronym      = 750
+	scoreWordBoundary = 700
+	scoreSubstring    = 500
+	scoreSubsequence  = 100
+)
+
+// FuzzyScore ranks how well candidate matches query, case insensitively. Exact and
+// prefix matches rank highest, followed by acronym ("sa" for "svc-api"), word
+// boundary and plain substring matches; any other in-order subsequence still matches.
+func FuzzyScore(candidate, query string) (int, bool) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return 0, true
+	}
+	lower := strings.ToLower(candidate)
+	starts := wordStarts(candidate)
+
+	switch {
+	case lower == query:
+		return scoreExact, true
+	case strings.HasPrefix(lower, query):
+		return scorePrefix - len(lower), true
+	}
+	if strings.HasPrefix(acronym(lower, starts), query) {
+		return scoreAcronym - len(lower), true
+	}
+	if index := strings.Index(lower, query); index >= 0 {
+		for offset := index; offset >= 0; {
+			if _, ok := starts[offset]; ok {
+				return scoreWordBoundary - offset, true
+			}
+			next := strings.Index(lower[offset+1:], query)
+			if next < 0 {
+				break
+			}
+			offset += next + 1
+		}
+		return scoreSubstring - index, true
+	}
+	return subsequenceScore(lower, query, starts)
+}
+
+// FuzzyMatch reports whether candidate matches query at all.
+func FuzzyMatch(candidate, query string) bool {
+	_, ok := FuzzyScore(candidate, query)
+	return ok
+}
+
+// FuzzyRank returns the indices of the matching candidates, best match first.
+func FuzzyRank(candidates []string, query string) []int {
+	type ranked struct {
+		index int
+		score int
+	}
+	matches := make([]ranked, 0, len(candidates))
+	for index, candidate := range candidates {
+		if score, ok := FuzzyScore(candidate, query); ok {
+			matches = append(matches, ranked{index: index, score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	result := make([]int, 0, len(matches))
+	for _, match := range matches {
+		result = append(result, match.index)
+	}
+	return result
+}
+
+// wordStarts returns the byte offsets in the lower case form of candidate where a
+// word begins: the first character, any character after a separator, and upper case
+// letters following lower case ones. Case is only known from candidate, so offsets
+// advance by the length of each lower case rune, which may differ from its own.
+func wordStarts(candidate string) map[int]struct{} {
+	starts := make(map[int]struct{})
+	var prev rune
+	offset := 0
+	for _, r := range candidate {
+		switch {
+		case offset == 0:
+			starts[offset] = struct{}{}
+		case !unicode.IsLetter(prev) && !unicode.IsDigit(prev) && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			starts[offset] = struct{}{}
+		case unicode.IsLower(prev) && unicode.IsUpper(r):
+			starts[offset] = struct{}{}
+		}
+		prev = r
+		offset += utf8.RuneLen(unicode.ToLower(r))
+	}
+	return starts
+}
+
+func acronym(lower string, starts map[int]struct{}) string {
+	var builder strings.Builder
+	for index, r := range lower {
+		if _, ok := starts[index]; ok {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+func subsequenceScore(lower, query string, starts map[int]struct{}) (int, bool) {
+	score := scoreSubsequence
+	queryRunes := []rune(query)
+	position := 0
+	last := -2
+	for index, r := range lower {
+		if position == len(queryRunes) {
+			break
+		}
+		if r != queryRunes[position] {
+			continue
+		}
+		if _, ok := starts[index]; ok {
+			score += 10
+		}
+		if index == last+1 {
+			score += 5
+		} else if last >= 0 {
+			score -= min(index-last, 10)
+		}
+		last = index
+		position++
+	}
+	if position < len(queryRunes) {
+		return 0, false
+	}
+	return min(score, scoreSubstring-1), true
+}
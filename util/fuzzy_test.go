@@ -0,0 +1,60 @@
+package util
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	cases := []struct {
+		candidate, query string
+		score            int
+		ok               bool
+	}{
+		{"svc-api", "", 0, true},
+		{"svc-api", "SVC-API", scoreExact, true},
+		{"svc-api", "svc", scorePrefix - 7, true},
+		{"svc-api", "sa", scoreAcronym - 7, true},
+		{"svcApi", "sa", scoreAcronym - 6, true},
+		{"team-svc-api", "api", scoreWordBoundary - 9, true},
+		{"rapid", "api", scoreSubstring - 1, true},
+		{"infra", "xyz", 0, false},
+		// İ lower cases to the shorter i, word starts are offsets in the lower case form.
+		{"İzmir-deploy", "deploy", scoreWordBoundary - 6, true},
+		{"İzmir-deploy", "id", scoreAcronym - 12, true},
+	}
+	for _, c := range cases {
+		score, ok := FuzzyScore(c.candidate, c.query)
+		if score != c.score || ok != c.ok {
+			t.Errorf("FuzzyScore(%q, %q) = %d, %v, want %d, %v", c.candidate, c.query, score, ok, c.score, c.ok)
+		}
+	}
+}
+
+func TestFuzzyRank(t *testing.T) {
+	cases := []struct {
+		query      string
+		candidates []string
+		expected   []string
+	}{
+		{
+			query:      "sa",
+			candidates: []string{"slab", "visa", "infra", "my-sa-tool", "svc-api", "saga", "sa"},
+			expected:   []string{"sa", "saga", "svc-api", "my-sa-tool", "visa", "slab"},
+		},
+		{
+			query:      "api",
+			candidates: []string{"amp-id", "rapid", "svc-api", "apigw", "api"},
+			expected:   []string{"api", "apigw", "svc-api", "rapid", "amp-id"},
+		},
+	}
+	for _, c := range cases {
+		ranked := make([]string, 0, len(c.candidates))
+		for _, index := range FuzzyRank(c.candidates, c.query) {
+			ranked = append(ranked, c.candidates[index])
+		}
+		if !reflect.DeepEqual(ranked, c.expected) {
+			t.Errorf("FuzzyRank(%q) = %v, want %v", c.query, ranked, c.expected)
+		}
+	}
+}
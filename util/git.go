@@ -0,0 +1,180 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FindGitDir walks up from dir to the nearest git repository and returns its git
+// directory, following the "gitdir:" indirection used by worktrees and submodules.
+func FindGitDir(dir string) (string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		candidate := filepath.Join(current, ".git")
+		info, err := os.Stat(candidate)
+		if err == nil {
+			if info.IsDir() {
+				return candidate, nil
+			}
+			data, err := os.ReadFile(candidate)
+			if err != nil {
+				return "", err
+			}
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+			if !ok {
+				return "", fmt.Errorf("unrecognized .git file at %s", candidate)
+			}
+			gitDir = strings.TrimSpace(gitDir)
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(current, gitDir)
+			}
+			return gitDir, nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", fmt.Errorf("not inside a git repository")
+		}
+		current = parent
+	}
+}
+
+// CurrentGitBranch returns the branch checked out in the repository containing the
+// working directory.
+func CurrentGitBranch() (string, error) {
+	return GitBranch(".")
+}
+
+// GitBranch returns the branch checked out in the repository containing dir.
+func GitBranch(dir string) (string, error) {
+	gitDir, err := FindGitDir(dir)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(filepath.Join(gitDir, "HEAD"))
+	if err != nil {
+		return "", fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	head := strings.TrimSpace(string(data))
+	branch, ok := strings.CutPrefix(head, "ref: refs/heads/")
+	if !ok {
+		return "", fmt.Errorf("HEAD is detached at %s", head)
+	}
+	return branch, nil
+}
+
+// GitRemoteURLs returns the URLs of all remotes configured for the repository
+// containing dir.
+func GitRemoteURLs(dir string) ([]string, error) {
+	gitDir, err := FindGitDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Worktrees keep their config in the common git directory.
+	if data, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		commonDir := strings.TrimSpace(string(data))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		gitDir = commonDir
+	}
+	data, err := os.ReadFile(filepath.Join(gitDir, "config"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git config: %w", err)
+	}
+	urls := make([]string, 0)
+	inRemote := false
+	for line := range strings.SplitSeq(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inRemote = strings.HasPrefix(line, "[remote ")
+			continue
+		}
+		if !inRemote {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(key) == "url" {
+			urls = append(urls, strings.TrimSpace(value))
+		}
+	}
+	return urls, nil
+}
+
+// NormalizeGitURL reduces the SSH, scp-like and HTTP forms of a repository URL to
+// "host/owner/repo" so that they can be compared.
+func NormalizeGitURL(rawURL string) string {
+	value := strings.TrimSpace(rawURL)
+	if value == "" {
+		return ""
+	}
+	if scheme, rest, ok := strings.Cut(value, "://"); ok && scheme != "" {
+		value = rest
+	} else if at := strings.Index(value, "@"); at >= 0 && strings.Contains(value[at:], ":") {
+		// scp-like syntax: git@host:owner/repo.git
+		value = strings.Replace(value[at+1:], ":", "/", 1)
+	}
+	if at := strings.Index(value, "@"); at >= 0 && at < strings.Index(value+"/", "/") {
+		value = value[at+1:]
+	}
+	host, path, _ := strings.Cut(value, "/")
+	if colon := strings.Index(host, ":"); colon >= 0 {
+		host = host[:colon]
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	return strings.ToLower(host + "/" + path)
+}
+
+// MatchGitBranch finds branch among the values offered by a git parameter, which may
+// carry a remote prefix such as "origin/".
+func MatchGitBranch(values []string, branch string) string {
+	if branch == "" {
+		return ""
+	}
+	for _, value := range values {
+		if value == branch {
+			return value
+		}
+	}
+	for _, value := range values {
+		if strings.HasSuffix(value, "/"+branch) || value == "refs/heads/"+branch {
+			return value
+		}
+	}
+	return ""
+}
+
+// ValidateBranchName applies the rules of git check-ref-format to a branch name
+// typed by the user.
+func ValidateBranchName(name string) error {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return fmt.Errorf("branch name cannot be empty")
+	case name == "@":
+		return fmt.Errorf("branch name cannot be '@'")
+	case strings.HasPrefix(name, "/") || strings.HasSuffix(name, "/"):
+		return fmt.Errorf("branch name cannot begin or end with '/'")
+	case strings.HasPrefix(name, "-"):
+		return fmt.Errorf("branch name cannot begin with '-'")
+	case strings.HasSuffix(name, ".") || strings.HasSuffix(name, ".lock"):
+		return fmt.Errorf("branch name cannot end with '.' or '.lock'")
+	case strings.Contains(name, "..") || strings.Contains(name, "//") || strings.Contains(name, "@{"):
+		return fmt.Errorf("branch name cannot contain '..', '//' or '@{'")
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f || strings.ContainsRune(" ~^:?*[\\", r) {
+			return fmt.Errorf("branch name cannot contain %q", r)
+		}
+	}
+	for part := range strings.SplitSeq(name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return fmt.Errorf("branch name components cannot begin with '.'")
+		}
+	}
+	return nil
+}
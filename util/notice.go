@@ -1,8 +1,189 @@
 package util
 
-import "os/exec"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/lemonsoul/jenkins-cli/api"
+	"github.com/lemonsoul/jenkins-cli/config"
+)
+
+var webhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// NotifyMac shows a notification through AppleScript. Title and message are passed
+// as arguments of the script rather than spliced into it, they hold job names and
+// descriptions that may contain quotes.
 func NotifyMac(title, message string) error {
-	cmd := exec.Command("osascript", "-e", `display notification "`+message+`" with title "`+title+`"`)
+	cmd := exec.Command("osascript",
+		"-e", "on run argv",
+		"-e", "display notification (item 1 of argv) with title (item 2 of argv)",
+		"-e", "end run",
+		message, title)
 	return cmd.Run()
 }
+
+// NotifyBuild sends the result of a finished build to every notifier of the account.
+// Without configured notifiers the terminal bell is rung.
+func NotifyBuild(account config.JenkinsConfig, build config.BuildSummary) []error {
+	notifiers := account.Notifiers
+	if len(notifiers) == 0 {
+		notifiers = []config.NotifierConfig{{Type: config.NOTIFIER_BELL}}
+	}
+	errs := make([]error, 0)
+	for _, notifier := range notifiers {
+		if len(notifier.On) > 0 && !slices.ContainsFunc(notifier.On, func(result string) bool {
+			return strings.EqualFold(result, build.Result)
+		}) {
+			continue
+		}
+		if err := notify(notifier, account, build); err != nil {
+			errs = append(errs, fmt.Errorf("%s notifier: %w", notifier.Type, err))
+		}
+	}
+	return errs
+}
+
+func notify(notifier config.NotifierConfig, account config.JenkinsConfig, build config.BuildSummary) error {
+	title := "Jenkins " + build.JobName + " #" + strconv.Itoa(build.Number)
+	message := BuildMessage(build)
+	buildUrl := BuildURL(account, build)
+	switch notifier.Type {
+	case config.NOTIFIER_BELL:
+		_, err := fmt.Fprint(os.Stderr, "\a")
+		return err
+	case config.NOTIFIER_DESKTOP:
+		return notifyDesktop(title, message)
+	case config.NOTIFIER_COMMAND:
+		return notifyCommand(notifier.Command, account, build, message, buildUrl)
+	case config.NOTIFIER_WEBHOOK:
+		return postWebhook(notifier.Url, map[string]any{
+			"account":     account.Name,
+			"job":         build.JobName,
+			"number":      build.Number,
+			"result":      build.Result,
+			"duration_ms": build.Duration,
+			"timestamp":   build.Timestamp,
+			"url":         buildUrl,
+		})
+	case config.NOTIFIER_SLACK:
+		return postWebhook(notifier.Url, map[string]any{
+			"text": fmt.Sprintf("%s\n<%s|Open build>", message, buildUrl),
+		})
+	case config.NOTIFIER_TEAMS:
+		return postWebhook(notifier.Url, map[string]any{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    title,
+			"themeColor": resultThemeColor(build.Result),
+			"title":      title,
+			"text":       fmt.Sprintf("%s\n\n[Open build](%s)", message, buildUrl),
+		})
+	case config.NOTIFIER_DINGTALK:
+		return postWebhook(notifier.Url, map[string]any{
+			"msgtype": "text",
+			"text":    map[string]string{"content": message + "\n" + buildUrl},
+		})
+	case config.NOTIFIER_FEISHU:
+		return postWebhook(notifier.Url, map[string]any{
+			"msg_type": "text",
+			"content":  map[string]string{"text": message + "\n" + buildUrl},
+		})
+	}
+	return fmt.Errorf("unknown notifier type %q", notifier.Type)
+}
+
+// BuildMessage is the one line summary sent for a finished build.
+func BuildMessage(build config.BuildSummary) string {
+	icon := "ℹ️"
+	switch build.Result {
+	case "SUCCESS":
+		icon = "✅"
+	case "FAILURE":
+		icon = "❌"
+	case "UNSTABLE":
+		icon = "⚠️"
+	case "ABORTED":
+		icon = "⏹"
+	}
+	duration := (time.Duration(build.Duration) * time.Millisecond).Round(time.Second)
+	return fmt.Sprintf("%s %s #%d %s in %s", icon, build.JobName, build.Number, build.Result, duration)
+}
+
+func BuildURL(account config.JenkinsConfig, build config.BuildSummary) string {
+	return fmt.Sprintf("%s%s/%d/", strings.TrimSuffix(account.BaseApi, "/"), api.JobPath(build.JobName), build.Number)
+}
+
+func notifyDesktop(title, message string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return NotifyMac(title, message)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.Command("notify-send", title, message).Run()
+	}
+	return fmt.Errorf("desktop notifications are not supported on %s, use a command notifier", runtime.GOOS)
+}
+
+func notifyCommand(command string, account config.JenkinsConfig, build config.BuildSummary, message, buildUrl string) error {
+	if command == "" {
+		return fmt.Errorf("no command configured")
+	}
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(),
+		"JENKINS_ACCOUNT="+account.Name,
+		"JENKINS_JOB="+build.JobName,
+		"JENKINS_BUILD="+strconv.Itoa(build.Number),
+		"JENKINS_RESULT="+build.Result,
+		"JENKINS_DURATION_MS="+strconv.FormatInt(build.Duration, 10),
+		"JENKINS_URL="+buildUrl,
+		"JENKINS_MESSAGE="+message,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func postWebhook(webhookUrl string, payload map[string]any) error {
+	if webhookUrl == "" {
+		return fmt.Errorf("no url configured")
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	response, err := webhookClient.Post(webhookUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered with status code: %d", response.StatusCode)
+	}
+	return nil
+}
+
+func resultThemeColor(result string) string {
+	switch result {
+	case "SUCCESS":
+		return "2EB886"
+	case "FAILURE":
+		return "D50000"
+	case "UNSTABLE":
+		return "FFB300"
+	}
+	return "9E9E9E"
+}
@@ -1,5 +1,10 @@
 package util
 
+import (
+	"fmt"
+	"strings"
+)
+
 func UpdateRecent(recent []string, value string, limit int) []string {
 	if value == "" {
 		return recent
@@ -47,3 +52,17 @@ func BuildAllowSet(items []string) map[string]struct{} {
 	}
 	return set
 }
+
+// ParseKeyValues parses "key=value" pairs as passed to repeatable command line flags.
+func ParseKeyValues(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid parameter %q, expected key=value", pair)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,55 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+const progressBarWidth = 30
+
+// ProgressBar renders a single-line progress indicator that is safe to update from
+// several goroutines.
+type ProgressBar struct {
+	mu      sync.Mutex
+	out     io.Writer
+	label   string
+	total   int
+	current int
+}
+
+func NewProgressBar(label string, total int) *ProgressBar {
+	bar := &ProgressBar{out: os.Stdout, label: label, total: total}
+	bar.render()
+	return bar
+}
+
+func (p *ProgressBar) Increment() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.current < p.total {
+		p.current++
+	}
+	p.render()
+}
+
+func (p *ProgressBar) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.current = p.total
+	p.render()
+	fmt.Fprintln(p.out)
+}
+
+func (p *ProgressBar) render() {
+	filled := progressBarWidth
+	if p.total > 0 {
+		filled = p.current * progressBarWidth / p.total
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	fmt.Fprintf(p.out, "\r%s %s %d/%d", p.label, color.CyanString(bar), p.current, p.total)
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// JobColorStatus translates the ball color Jenkins reports for a job into the
+// result of its last build, e.g. "blue_anime" becomes "BUILDING".
+func JobColorStatus(jobColor string) string {
+	if strings.HasSuffix(jobColor, "_anime") {
+		return "BUILDING"
+	}
+	switch jobColor {
+	case "blue", "green":
+		return "SUCCESS"
+	case "red":
+		return "FAILURE"
+	case "yellow":
+		return "UNSTABLE"
+	case "aborted":
+		return "ABORTED"
+	case "disabled", "grey":
+		return "DISABLED"
+	case "notbuilt":
+		return "NOT_BUILT"
+	}
+	return "UNKNOWN"
+}
+
+// ColorizeStatus renders a build result with the color used across the CLI.
+func ColorizeStatus(status string) string {
+	switch status {
+	case "SUCCESS":
+		return color.GreenString("● %s", status)
+	case "FAILURE":
+		return color.RedString("● %s", status)
+	case "UNSTABLE", "ABORTED":
+		return color.YellowString("● %s", status)
+	case "BUILDING", "IN_PROGRESS":
+		return color.CyanString("● %s", status)
+	}
+	return color.WhiteString("● %s", status)
+}
@@ -0,0 +1,61 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var templatePattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.]+)\s*\}\}`)
+
+// ExpandTemplate replaces the placeholders supported in preset values:
+// {{git.branch}}, {{env.NAME}}, {{date}} (YYYY-MM-DD) and {{time}} (HHMMSS).
+func ExpandTemplate(value string) (string, error) {
+	var expandErr error
+	expanded := templatePattern.ReplaceAllStringFunc(value, func(match string) string {
+		key := templatePattern.FindStringSubmatch(match)[1]
+		resolved, err := resolveTemplateKey(key)
+		if err != nil && expandErr == nil {
+			expandErr = fmt.Errorf("failed to expand %s: %w", match, err)
+		}
+		return resolved
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
+
+// ExpandTemplates expands every value of params and returns a new map.
+func ExpandTemplates(params map[string]string) (map[string]string, error) {
+	expanded := make(map[string]string, len(params))
+	for key, value := range params {
+		result, err := ExpandTemplate(value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", key, err)
+		}
+		expanded[key] = result
+	}
+	return expanded, nil
+}
+
+func resolveTemplateKey(key string) (string, error) {
+	if name, ok := strings.CutPrefix(key, "env."); ok {
+		value, found := os.LookupEnv(name)
+		if !found {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	}
+	switch key {
+	case "git.branch":
+		return CurrentGitBranch()
+	case "date":
+		return time.Now().Format("2006-01-02"), nil
+	case "time":
+		return time.Now().Format("150405"), nil
+	}
+	return "", fmt.Errorf("unknown template key %q", key)
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	t.Setenv("JENKINS_CLI_TARGET", "staging")
+	cases := map[string]string{
+		"plain value":                     "plain value",
+		"{{env.JENKINS_CLI_TARGET}}":      "staging",
+		"to-{{ env.JENKINS_CLI_TARGET }}": "to-staging",
+		"{not a template}":                "{not a template}",
+	}
+	for value, expected := range cases {
+		if got, err := ExpandTemplate(value); err != nil || got != expected {
+			t.Errorf("ExpandTemplate(%q) = %q, %v, want %q", value, got, err, expected)
+		}
+	}
+
+	stamped, err := ExpandTemplate("{{date}}_{{time}}")
+	if err != nil || !regexp.MustCompile(`^\d{4}-\d{2}-\d{2}_\d{6}$`).MatchString(stamped) {
+		t.Errorf("ExpandTemplate of date and time = %q, %v", stamped, err)
+	}
+}
+
+func TestExpandTemplateErrors(t *testing.T) {
+	t.Setenv("JENKINS_CLI_UNSET", "")
+	os.Unsetenv("JENKINS_CLI_UNSET")
+	cases := map[string]string{
+		"{{branch}}":                 `unknown template key "branch"`,
+		"{{env.JENKINS_CLI_UNSET}}":  "environment variable JENKINS_CLI_UNSET is not set",
+		"ok-{{date}}-{{git.commit}}": `failed to expand {{git.commit}}`,
+	}
+	for value, expected := range cases {
+		if got, err := ExpandTemplate(value); err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("ExpandTemplate(%q) = %q, %v, want an error containing %q", value, got, err, expected)
+		}
+	}
+}
+
+func TestExpandTemplateGitBranch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/feature/login\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	if got, err := ExpandTemplate("{{git.branch}}"); err != nil || got != "feature/login" {
+		t.Errorf("ExpandTemplate(git.branch) = %q, %v", got, err)
+	}
+}
+
+func TestExpandTemplates(t *testing.T) {
+	t.Setenv("JENKINS_CLI_TARGET", "staging")
+	params := map[string]string{"env": "{{env.JENKINS_CLI_TARGET}}", "tag": "v1"}
+	expanded, err := ExpandTemplates(params)
+	if err != nil || expanded["env"] != "staging" || expanded["tag"] != "v1" {
+		t.Errorf("ExpandTemplates = %v, %v", expanded, err)
+	}
+	if params["env"] != "{{env.JENKINS_CLI_TARGET}}" {
+		t.Errorf("ExpandTemplates modified its argument: %v", params)
+	}
+	if _, err := ExpandTemplates(map[string]string{"tag": "{{nope}}"}); err == nil || !strings.HasPrefix(err.Error(), "parameter tag:") {
+		t.Errorf("ExpandTemplates with an unknown key = %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/fatih/color"
@@ -16,12 +17,17 @@ type QueueSelectItem struct {
 }
 
 type BaseSelectItem struct {
-	Name     string
-	Value    interface{}
-	IsRecent bool
+	Name       string
+	Value      interface{}
+	IsRecent   bool
+	IsFavorite bool
+	IsAlias    bool
 }
 
 const recentPrefix = "[Recent] "
+const favoritePrefix = "[★] "
+const currentPrefix = "[Current] "
+const aliasPrefix = "⚡ "
 
 func QueueUISelect(label string, items []QueueSelectItem) int {
 
@@ -51,15 +57,137 @@ func QueueUISelect(label string, items []QueueSelectItem) int {
 	return index
 }
 
+type JobSelectItem struct {
+	Name    string
+	Views   string
+	Status  string
+	Display string
+}
+
+// JobUISelect shows jobs with their views and last build status and returns the
+// selected index, or -1 when nothing was selected.
+func JobUISelect(label string, items []JobSelectItem) int {
+	if len(items) == 0 {
+		return -1
+	}
+	template := &promptui.SelectTemplates{
+		Label:    "{{ . }}? (Press Ctrl+C or 'q' to quit)",
+		Active:   "\U0001F34B {{ .Display }}",
+		Inactive: "  {{ .Display }}",
+		Selected: "\u2714 {{ .Name | cyan }}",
+		Details: `
+--------- Pepper ----------
+{{ "Name:" | faint }}	{{ .Name }}
+{{ "Views:" | faint }}	{{ .Views }}
+{{ "Last Build:" | faint }}	{{ .Status }}`,
+	}
+	searcher := func(input string, index int) bool {
+		if input == "q" || input == "Q" {
+			fmt.Println()
+			color.Yellow("👋 Exiting...")
+			os.Exit(0)
+		}
+		return FuzzyMatch(items[index].Name, input)
+	}
+	selectPrompt := &promptui.Select{
+		Label:     label,
+		Items:     items,
+		Templates: template,
+		Size:      10,
+		Searcher:  searcher,
+	}
+	index, _, err := selectPrompt.Run()
+	if err != nil {
+		if err.Error() == "^C" || err.Error() == "interrupt" {
+			fmt.Println()
+			color.Yellow("👋 Exiting...")
+			os.Exit(0)
+		}
+		color.Yellow("failed to select")
+		return -1
+	}
+	return index
+}
+
+// PromptText reads a single line of free text input.
+func PromptText(label string, validate func(string) error) string {
+	prompt := promptui.Prompt{
+		Label:    label,
+		Validate: validate,
+	}
+	value, err := prompt.Run()
+	if err != nil {
+		if err.Error() == "^C" || err.Error() == "interrupt" {
+			fmt.Println()
+			color.Yellow("👋 Exiting...")
+			os.Exit(0)
+		}
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// Confirm asks a yes/no question, answering no on anything but y.
+func Confirm(label string) bool {
+	prompt := promptui.Prompt{
+		Label:     label,
+		IsConfirm: true,
+	}
+	_, err := prompt.Run()
+	return err == nil
+}
+
 func StrUISelect(label string, itemStrs []string) string {
 	return strUISelect(label, itemStrs)
 }
 
 func StrUISelectWithRecent(label string, itemStrs []string, recent []string) string {
-	items, mapping := buildRecentItems(itemStrs, recent)
+	return StrUISelectWithFavorites(label, itemStrs, nil, recent)
+}
+
+// StrUISelectWithFavorites lists favorites first, then recent values, then all items.
+func StrUISelectWithFavorites(label string, itemStrs []string, favorites []string, recent []string) string {
+	items, mapping := buildRecentItems(itemStrs, favorites, recent)
 	return strUISelectItems(label, items, mapping)
 }
 
+// StrUISelectWithAliases is StrUISelectWithFavorites listing aliases above the
+// favorites. It returns the selected item, or an empty item and the index of the
+// selected alias, which is -1 when no alias was selected.
+func StrUISelectWithAliases(label string, aliases []string, itemStrs []string, favorites []string, recent []string) (string, int) {
+	items, mapping := buildRecentItems(itemStrs, favorites, recent)
+	listed := make([]BaseSelectItem, 0, len(aliases)+len(items))
+	for _, alias := range aliases {
+		listed = append(listed, BaseSelectItem{Name: aliasPrefix + alias, Value: alias, IsAlias: true})
+	}
+	index := strUISelectIndex(label, append(listed, items...))
+	switch {
+	case index < 0:
+		return "", -1
+	case index < len(aliases):
+		return "", index
+	}
+	return selectedValue(items, mapping, index-len(aliases)), -1
+}
+
+// StrUISelectWithCurrent preselects current, such as the checked out git branch,
+// ahead of the recent values and all items.
+func StrUISelectWithCurrent(label string, itemStrs []string, current string, recent []string) string {
+	if current == "" {
+		return StrUISelectWithRecent(label, itemStrs, recent)
+	}
+	recent = slices.DeleteFunc(slices.Clone(recent), func(value string) bool {
+		return value == current
+	})
+	items, mapping := buildRecentItems(itemStrs, nil, recent)
+	shifted := map[int]string{0: current}
+	for index, value := range mapping {
+		shifted[index+1] = value
+	}
+	items = append([]BaseSelectItem{{Name: currentPrefix + current, Value: current, IsRecent: true}}, items...)
+	return strUISelectItems(label, items, shifted)
+}
+
 func strUISelect(label string, itemStrs []string) string {
 	if len(itemStrs) == 0 {
 		//color.Red("No items to select")
@@ -73,15 +201,25 @@ func strUISelect(label string, itemStrs []string) string {
 }
 
 func strUISelectItems(label string, items []BaseSelectItem, mapping map[int]string) string {
-	if len(items) == 0 {
+	index := strUISelectIndex(label, items)
+	if index < 0 {
 		return ""
 	}
+	return selectedValue(items, mapping, index)
+}
+
+// strUISelectIndex returns the index of the selected item, or -1 when nothing was
+// selected.
+func strUISelectIndex(label string, items []BaseSelectItem) int {
+	if len(items) == 0 {
+		return -1
+	}
 
 	template := &promptui.SelectTemplates{
 		Label:    "{{ . }}? (Press Ctrl+C or 'q' to quit)",
-		Active:   "{{ if .IsRecent }}\U0001F34B {{ .Name | yellow }}{{ else }}\U0001F34B {{ .Name | cyan }}{{ end }}",
-		Inactive: "{{ if .IsRecent }}  {{ .Name | yellow }}{{ else }}  {{ .Name | cyan }}{{ end }}",
-		Selected: "{{ if .IsRecent }}\u2714 {{ .Name | yellow }}{{ else }}\u2714 {{ .Name | cyan }}{{ end }}",
+		Active:   "{{ if .IsAlias }}\U0001F34B {{ .Name | green }}{{ else if .IsFavorite }}\U0001F34B {{ .Name | magenta }}{{ else if .IsRecent }}\U0001F34B {{ .Name | yellow }}{{ else }}\U0001F34B {{ .Name | cyan }}{{ end }}",
+		Inactive: "{{ if .IsAlias }}  {{ .Name | green }}{{ else if .IsFavorite }}  {{ .Name | magenta }}{{ else if .IsRecent }}  {{ .Name | yellow }}{{ else }}  {{ .Name | cyan }}{{ end }}",
+		Selected: "{{ if .IsAlias }}\u2714 {{ .Name | green }}{{ else if .IsFavorite }}\u2714 {{ .Name | magenta }}{{ else if .IsRecent }}\u2714 {{ .Name | yellow }}{{ else }}\u2714 {{ .Name | cyan }}{{ end }}",
 		Details: `
 --------- Pepper ----------
 {{ "Name:" | faint }}	{{ .Name }}`,
@@ -120,9 +258,12 @@ func strUISelectItems(label string, items []BaseSelectItem, mapping map[int]stri
 			os.Exit(0)
 		}
 		color.Yellow("failed to select")
-		return ""
+		return -1
 	}
+	return index
+}
 
+func selectedValue(items []BaseSelectItem, mapping map[int]string, index int) string {
 	if mapping != nil {
 		if value, ok := mapping[index]; ok {
 			return value
@@ -137,18 +278,36 @@ func strUISelectItems(label string, items []BaseSelectItem, mapping map[int]stri
 	return ""
 }
 
-// contains checks if a string contains a substring (case insensitive)
+// contains checks if a string fuzzily matches the search input (case insensitive)
 func contains(str, substr string) bool {
-	return strings.Contains(strings.ToLower(str), strings.ToLower(substr))
+	for _, prefix := range []string{aliasPrefix, favoritePrefix, recentPrefix, currentPrefix} {
+		str = strings.TrimPrefix(str, prefix)
+	}
+	return FuzzyMatch(str, substr)
 }
 
-func buildRecentItems(items []string, recent []string) ([]BaseSelectItem, map[int]string) {
-	if len(items) == 0 && len(recent) == 0 {
+func buildRecentItems(items []string, favorites []string, recent []string) ([]BaseSelectItem, map[int]string) {
+	if len(items) == 0 && len(recent) == 0 && len(favorites) == 0 {
 		return []BaseSelectItem{}, nil
 	}
-	recentSet := make(map[string]struct{}, len(recent))
-	merged := make([]BaseSelectItem, 0, len(items)+len(recent))
+	recentSet := make(map[string]struct{}, len(favorites)+len(recent))
+	merged := make([]BaseSelectItem, 0, len(items)+len(favorites)+len(recent))
 	indexMapping := make(map[int]string)
+	for _, value := range favorites {
+		if value == "" {
+			continue
+		}
+		if _, ok := recentSet[value]; ok {
+			continue
+		}
+		recentSet[value] = struct{}{}
+		indexMapping[len(merged)] = value
+		merged = append(merged, BaseSelectItem{
+			Name:       favoritePrefix + value,
+			Value:      value,
+			IsFavorite: true,
+		})
+	}
 	for _, value := range recent {
 		if value == "" {
 			continue
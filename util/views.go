@@ -0,0 +1,37 @@
+package util
+
+import "github.com/lemonsoul/jenkins-cli/config"
+
+// AllViews returns every view of the workspace tree, each parent before its nested
+// views. The pointers refer into cfg, so callers may update the views in place.
+func AllViews(cfg *config.Workspace) []*config.View {
+	views := make([]*config.View, 0, len(cfg.Views))
+	var walk func(children []config.View)
+	walk = func(children []config.View) {
+		for index := range children {
+			views = append(views, &children[index])
+			walk(children[index].Views)
+		}
+	}
+	walk(cfg.Views)
+	return views
+}
+
+// FindView looks a view up by its full name anywhere in the tree.
+func FindView(cfg *config.Workspace, name string) *config.View {
+	for _, view := range AllViews(cfg) {
+		if view.Name == name {
+			return view
+		}
+	}
+	return nil
+}
+
+// AllViewNames returns the full names of every view in the tree.
+func AllViewNames(cfg *config.Workspace) []string {
+	names := make([]string, 0, len(cfg.Views))
+	for _, view := range AllViews(cfg) {
+		names = append(names, view.Name)
+	}
+	return names
+}
@@ -0,0 +1,116 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lemonsoul/jenkins-cli/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Finished builds are dropped from the watch state after this long.
+const watchRetention = 7 * 24 * time.Hour
+const watchLockTimeout = 5 * time.Second
+
+// A lock older than this was left behind by a killed process.
+const watchStaleLock = 30 * time.Second
+
+func GetWatchStateFilePath() string {
+	return os.Getenv("HOME") + config.WATCH_STATE_DIR
+}
+
+func GetWatchLogFilePath() string {
+	return os.Getenv("HOME") + config.WATCH_LOG_DIR
+}
+
+func LoadWatchState() (config.WatchState, error) {
+	var state config.WatchState
+	data, err := os.ReadFile(GetWatchStateFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("invalid watch state: %w", err)
+	}
+	return state, nil
+}
+
+// UpdateWatchState applies update to the watch state under a lock file, the
+// commands and the background watcher write the file concurrently.
+func UpdateWatchState(update func(*config.WatchState)) (config.WatchState, error) {
+	statePath := GetWatchStateFilePath()
+	if err := os.MkdirAll(filepath.Dir(statePath), 0755); err != nil {
+		return config.WatchState{}, err
+	}
+	unlock, err := lockFile(statePath + ".lock")
+	if err != nil {
+		return config.WatchState{}, err
+	}
+	defer unlock()
+
+	state, err := LoadWatchState()
+	if err != nil {
+		return state, err
+	}
+	update(&state)
+	pruneWatchState(&state, time.Now())
+
+	data, err := yaml.Marshal(&state)
+	if err != nil {
+		return state, err
+	}
+	tmpPath := statePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return state, err
+	}
+	return state, os.Rename(tmpPath, statePath)
+}
+
+// PendingWatches returns the tracked builds without a result yet.
+func PendingWatches(state config.WatchState) []config.WatchedBuild {
+	pending := make([]config.WatchedBuild, 0)
+	for _, build := range state.Builds {
+		if build.FinishedAt.IsZero() {
+			pending = append(pending, build)
+		}
+	}
+	return pending
+}
+
+func pruneWatchState(state *config.WatchState, now time.Time) {
+	kept := state.Builds[:0]
+	for _, build := range state.Builds {
+		if build.FinishedAt.IsZero() || now.Sub(build.FinishedAt) < watchRetention {
+			kept = append(kept, build)
+		}
+	}
+	state.Builds = kept
+}
+
+func lockFile(lockPath string) (func(), error) {
+	deadline := time.Now().Add(watchLockTimeout)
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			file.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > watchStaleLock {
+			os.Remove(lockPath)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("watch state is locked, remove %s if no jenkins-cli is running", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}